@@ -0,0 +1,117 @@
+package diffanalyzer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// byteWindowContext is the number of equal bytes shown around the
+	// differing ones in a ByteWindow.
+	byteWindowContext = 4
+	// maxByteWindow caps the number of bytes shown in a ByteWindow.
+	maxByteWindow = 64
+)
+
+// WithCompactBytes compares []byte and [N]byte values as a whole and reports
+// a single difference holding a ByteWindow on each side, instead of one
+// difference per byte. Combine it with ForPath or ForType to restrict it to
+// some values.
+func WithCompactBytes() Option {
+	return func(o *options) {
+		o.node.compactBytes = true
+	}
+}
+
+// ByteWindow is a hex view of the part of a byte sequence where it differs
+// from the other side of the comparison.
+type ByteWindow struct {
+	// Offset is the position of Bytes[0] in the whole sequence.
+	Offset int
+	// Bytes is the window itself.
+	Bytes []byte
+	// Length is the length of the whole sequence.
+	Length int
+	// Differs lists the offsets, within the whole sequence, of the bytes in
+	// the window that differ from the other side.
+	Differs []int
+}
+
+// String renders the window as e.g. "len=16 @0x0004: 04 05 [ff] 07 …", with
+// the differing bytes in brackets and ellipses marking the elided parts.
+func (w ByteWindow) String() string {
+	differs := make(map[int]bool, len(w.Differs))
+	for _, offset := range w.Differs {
+		differs[offset] = true
+	}
+
+	parts := make([]string, 0, len(w.Bytes)+2)
+	if w.Offset > 0 {
+		parts = append(parts, "…")
+	}
+	for i, b := range w.Bytes {
+		if differs[w.Offset+i] {
+			parts = append(parts, fmt.Sprintf("[%02x]", b))
+		} else {
+			parts = append(parts, fmt.Sprintf("%02x", b))
+		}
+	}
+	if w.Offset+len(w.Bytes) < w.Length {
+		parts = append(parts, "…")
+	}
+	return fmt.Sprintf("len=%d @0x%04x: %s", w.Length, w.Offset, strings.Join(parts, " "))
+}
+
+// isByteSequence reports whether t is a slice or array of bytes.
+func isByteSequence(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8
+}
+
+// compareBytes reports at most one difference between two byte sequences,
+// showing a window around the differing offsets.
+func (c *comparer) compareBytes(expected, actual reflect.Value, path Path) {
+	expectedBytes, actualBytes := byteContents(expected), byteContents(actual)
+
+	var differs []int
+	for i := range max(len(expectedBytes), len(actualBytes)) {
+		if i >= len(expectedBytes) || i >= len(actualBytes) || expectedBytes[i] != actualBytes[i] {
+			differs = append(differs, i)
+		}
+	}
+	if len(differs) == 0 {
+		return
+	}
+
+	start := max(differs[0]-byteWindowContext, 0)
+	end := min(differs[len(differs)-1]+byteWindowContext+1, start+maxByteWindow)
+	c.report(FieldDiff{
+		Path:     path,
+		Expected: newByteWindow(expectedBytes, start, end, differs),
+		Actual:   newByteWindow(actualBytes, start, end, differs),
+		Change:   Modified,
+	})
+}
+
+func newByteWindow(data []byte, start, end int, differs []int) ByteWindow {
+	window := ByteWindow{Offset: start, Length: len(data)}
+	if start < len(data) {
+		window.Bytes = data[start:min(end, len(data))]
+	}
+	for _, offset := range differs {
+		if offset >= start && offset < start+len(window.Bytes) {
+			window.Differs = append(window.Differs, offset)
+		}
+	}
+	return window
+}
+
+// byteContents copies the bytes of a byte slice or array, which need not be
+// addressable.
+func byteContents(v reflect.Value) []byte {
+	data := make([]byte, v.Len())
+	for i := range data {
+		data[i] = byte(v.Index(i).Uint())
+	}
+	return data
+}
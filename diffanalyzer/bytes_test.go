@@ -0,0 +1,104 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type blob struct {
+	Checksum [4]byte
+	Counts   [3]int
+	Data     []byte
+}
+
+func TestFindDifferences_Arrays_ShouldReportPositionalDifferences(t *testing.T) {
+	// Arrange
+	blob1 := blob{Counts: [3]int{1, 2, 3}}
+	blob2 := blob{Counts: [3]int{1, 5, 3}}
+
+	// Act
+	diffs := FindDifferences(blob1, blob2)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Counts.[1]", diffs[0].Path.String())
+	assert.Equal(t, 2, diffs[0].Expected)
+	assert.Equal(t, 5, diffs[0].Actual)
+}
+
+func TestFindDifferences_IdenticalArrays_ShouldReturnNoDifferences(t *testing.T) {
+	// Arrange
+	blob1 := blob{Checksum: [4]byte{0xde, 0xad, 0xbe, 0xef}, Counts: [3]int{1, 2, 3}}
+	blob2 := blob{Checksum: [4]byte{0xde, 0xad, 0xbe, 0xef}, Counts: [3]int{1, 2, 3}}
+
+	// Act
+	diffs := FindDifferences(blob1, blob2)
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFindDifferences_RootArray_ShouldNotPanic(t *testing.T) {
+	// Act
+	diffs := FindDifferences([2]string{"a", "b"}, [2]string{"a", "c"})
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "[1]", diffs[0].Path.String())
+}
+
+func TestFindDifferencesWithOptions_CompactBytes_ShouldReportSingleHexWindow(t *testing.T) {
+	// Arrange
+	data1 := make([]byte, 32)
+	data2 := make([]byte, 32)
+	for i := range data1 {
+		data1[i] = byte(i)
+		data2[i] = byte(i)
+	}
+	data2[10] = 0xff
+	data2[12] = 0xee
+
+	// Act
+	diffs := FindDifferencesWithOptions(blob{Data: data1}, blob{Data: data2}, WithCompactBytes())
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Data", diffs[0].Path.String())
+
+	expected := diffs[0].Expected.(ByteWindow)
+	actual := diffs[0].Actual.(ByteWindow)
+	assert.Equal(t, 6, expected.Offset)
+	assert.Equal(t, []int{10, 12}, expected.Differs)
+	assert.Equal(t, "len=32 @0x0006: … 06 07 08 09 [0a] 0b [0c] 0d 0e 0f 10 …", expected.String())
+	assert.Equal(t, "len=32 @0x0006: … 06 07 08 09 [ff] 0b [ee] 0d 0e 0f 10 …", actual.String())
+}
+
+func TestFindDifferencesWithOptions_CompactBytesDifferentLengths_ShouldShowTail(t *testing.T) {
+	// Act
+	diffs := FindDifferencesWithOptions([]byte{1, 2, 3}, []byte{1, 2, 3, 4, 5}, WithCompactBytes())
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "len=3 @0x0000: 01 02 03", FormatDiffValue(diffs[0].Expected))
+	assert.Equal(t, "len=5 @0x0000: 01 02 03 [04] [05]", FormatDiffValue(diffs[0].Actual))
+}
+
+func TestFindDifferencesWithOptions_CompactBytesForArrays_ShouldReportSingleDiff(t *testing.T) {
+	// Arrange
+	blob1 := blob{Checksum: [4]byte{0xde, 0xad, 0xbe, 0xef}}
+	blob2 := blob{Checksum: [4]byte{0xde, 0xad, 0xbe, 0xee}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(blob1, blob2, ForPath("Checksum", WithCompactBytes()))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Checksum", diffs[0].Path.String())
+	assert.Equal(t, "len=4 @0x0000: de ad be [ef]", FormatDiffValue(diffs[0].Expected))
+}
+
+func TestFormatComparisonValue_Array_ShouldNotPanic(t *testing.T) {
+	assert.Equal(t, "[1, 2, 3]", FormatComparisonValue([3]int{1, 2, 3}))
+	assert.Equal(t, "[1, 2]", FormatTestOutput([2]int{1, 2}))
+}
@@ -0,0 +1,68 @@
+package diffanalyzer
+
+import "reflect"
+
+// ChangeType classifies the kind of difference a FieldDiff describes.
+type ChangeType int
+
+const (
+	// Modified means the value exists on both sides but differs.
+	Modified ChangeType = iota
+	// Added means the value exists only in actual.
+	Added
+	// Removed means the value exists only in expected.
+	Removed
+	// TypeChanged means both sides hold values of different types.
+	TypeChanged
+	// NilVsEmpty means one side is nil and the other an empty slice or map.
+	NilVsEmpty
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case Modified:
+		return "modified"
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case TypeChanged:
+		return "type-changed"
+	case NilVsEmpty:
+		return "nil-vs-empty"
+	default:
+		return "unknown"
+	}
+}
+
+// nilChange classifies a difference where exactly one of expected and actual
+// is nil. Both values must be of a nillable kind.
+func nilChange(expected, actual reflect.Value) ChangeType {
+	if expected.IsNil() {
+		if isEmptyContainer(actual) {
+			return NilVsEmpty
+		}
+		return Added
+	}
+	if isEmptyContainer(expected) {
+		return NilVsEmpty
+	}
+	return Removed
+}
+
+// presenceChange classifies a difference where the value exists on only one
+// side: Removed when it exists in expected, Added otherwise.
+func presenceChange(inExpected bool) ChangeType {
+	if inExpected {
+		return Removed
+	}
+	return Added
+}
+
+func isEmptyContainer(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
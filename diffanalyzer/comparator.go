@@ -0,0 +1,54 @@
+package diffanalyzer
+
+import "reflect"
+
+// comparator compares two values of the type it was registered for and
+// returns the differences found at path.
+type comparator func(expected, actual reflect.Value, path Path) []FieldDiff
+
+// WithComparator registers equal as the equality of values of type T, e.g.
+// decimals, IPs or UUIDs whose fields do not reflect their meaning. When equal
+// reports false a single Modified difference holding both values is reported
+// at their path; their fields are never traversed.
+func WithComparator[T any](equal func(expected, actual T) bool) Option {
+	return WithDiffFunc(func(expected, actual T, path Path) []FieldDiff {
+		if equal(expected, actual) {
+			return nil
+		}
+		return []FieldDiff{{Path: path, Expected: expected, Actual: actual, Change: Modified}}
+	})
+}
+
+// WithDiffFunc registers diff as the comparison of values of type T. It
+// receives the path of the values and returns the differences to report,
+// which may point below path. Registering a type again replaces the previous
+// comparator.
+func WithDiffFunc[T any](diff func(expected, actual T, path Path) []FieldDiff) Option {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	return func(o *options) {
+		if o.comparators == nil {
+			o.comparators = make(map[reflect.Type]comparator)
+		}
+		o.comparators[t] = func(expected, actual reflect.Value, path Path) []FieldDiff {
+			e, _ := expected.Interface().(T)
+			a, _ := actual.Interface().(T)
+			return diff(e, a, path)
+		}
+	}
+}
+
+// compareCustom compares expected and actual with the comparator registered
+// for their type, if any, and reports whether it did.
+func (c *comparer) compareCustom(expected, actual reflect.Value, path Path) bool {
+	if expected.Type() != actual.Type() {
+		return false
+	}
+	compare, found := c.opts.comparators[expected.Type()]
+	if !found || !expected.CanInterface() || !actual.CanInterface() {
+		return false
+	}
+	for _, diff := range compare(expected, actual, path) {
+		c.report(diff)
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package diffanalyzer
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// money is a decimal amount whose scale does not change its value.
+type money struct {
+	Units int64
+	Scale int
+}
+
+func (m money) cents() int64 {
+	units := m.Units
+	for i := m.Scale; i < 2; i++ {
+		units *= 10
+	}
+	for i := m.Scale; i > 2; i-- {
+		units /= 10
+	}
+	return units
+}
+
+type invoice struct {
+	Number string
+	Total  money
+	Server net.IP
+	Extra  interface{}
+}
+
+func TestFindDifferences_WithComparator_ShouldUseRegisteredEquality(t *testing.T) {
+	// Arrange
+	expected := invoice{Number: "A-1", Total: money{Units: 1050, Scale: 2}, Server: net.ParseIP("10.0.0.1")}
+	actual := invoice{Number: "A-1", Total: money{Units: 105, Scale: 1}, Server: net.ParseIP("10.0.0.1").To4()}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual,
+		WithComparator(func(a, b money) bool { return a.cents() == b.cents() }),
+		WithComparator(func(a, b net.IP) bool { return a.Equal(b) }),
+	)
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFindDifferences_WithComparator_ShouldReportWholeValue(t *testing.T) {
+	// Arrange
+	expected := invoice{Total: money{Units: 1050, Scale: 2}}
+	actual := invoice{Total: money{Units: 1060, Scale: 2}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual,
+		WithComparator(func(a, b money) bool { return a.cents() == b.cents() }))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Total", diffs[0].Path.String())
+	assert.Equal(t, Modified, diffs[0].Change)
+	assert.Equal(t, money{Units: 1050, Scale: 2}, diffs[0].Expected)
+	assert.Equal(t, money{Units: 1060, Scale: 2}, diffs[0].Actual)
+}
+
+func TestFindDifferences_WithDiffFunc_ShouldReportReturnedDifferences(t *testing.T) {
+	// Arrange
+	expected := []invoice{{Number: "a-1", Total: money{Units: 1}}}
+	actual := []invoice{{Number: "A-1", Total: money{Units: 2}}}
+	numbers := func(a, b invoice, path Path) []FieldDiff {
+		if strings.EqualFold(a.Number, b.Number) {
+			return nil
+		}
+		return []FieldDiff{{Path: path.Field("Number"), Expected: a.Number, Actual: b.Number}}
+	}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithDiffFunc(numbers))
+
+	// Assert
+	assert.Empty(t, diffs, "Only the registered comparison should run for invoices")
+}
+
+func TestFindDifferences_WithDiffFunc_ShouldHonorIgnoredPaths(t *testing.T) {
+	// Arrange
+	expected := invoice{Number: "A-1"}
+	actual := invoice{Number: "A-2"}
+	numbers := func(a, b invoice, path Path) []FieldDiff {
+		if a.Number == b.Number {
+			return nil
+		}
+		return []FieldDiff{{Path: path.Field("Number"), Expected: a.Number, Actual: b.Number}}
+	}
+
+	// Act
+	reported := FindDifferencesWithOptions(expected, actual, WithDiffFunc(numbers))
+	ignored := FindDifferencesWithOptions(expected, actual, WithDiffFunc(numbers), WithIgnoredPaths("Number"))
+
+	// Assert
+	assert.Len(t, reported, 1)
+	assert.Equal(t, "Number", reported[0].Path.String())
+	assert.Empty(t, ignored)
+}
+
+func TestFindDifferences_WithComparator_ShouldApplyToInterfaceValues(t *testing.T) {
+	// Arrange
+	expected := invoice{Extra: money{Units: 10, Scale: 1}}
+	actual := invoice{Extra: money{Units: 100, Scale: 2}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual,
+		WithComparator(func(a, b money) bool { return a.cents() == b.cents() }))
+
+	// Assert
+	assert.Empty(t, diffs)
+}
@@ -0,0 +1,85 @@
+package diffanalyzer
+
+import "reflect"
+
+// visit identifies a pointer, map or slice being traversed. Slices also
+// carry their length, since a slice and a shorter view of it share the same
+// address.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+func newVisit(v reflect.Value) visit {
+	key := visit{ptr: v.Pointer(), typ: v.Type()}
+	if v.Kind() == reflect.Slice {
+		key.len = v.Len()
+	}
+	return key
+}
+
+// CycleRef stands for a value that refers back to one of its own ancestors,
+// found at Path. It is reported in a FieldDiff when the expected and the
+// actual graphs do not loop back to the same place.
+type CycleRef struct {
+	Path Path
+}
+
+func (r CycleRef) String() string {
+	if len(r.Path) == 0 {
+		return "<cycle to root>"
+	}
+	return "<cycle to " + r.Path.String() + ">"
+}
+
+// enter marks the non-nil pointers, maps or slices expected and actual as
+// being traversed at path. When either of them is already an ancestor of
+// path the graphs are cyclic: enter reports a difference unless both sides
+// loop back to the same path, and returns false so that the caller stops
+// descending. Otherwise it returns true and the caller must call leave once
+// done with the values.
+func (c *comparer) enter(expected, actual reflect.Value, path Path) bool {
+	expectedVisit, actualVisit := newVisit(expected), newVisit(actual)
+	expectedAncestor, expectedCycle := c.expectedStack[expectedVisit]
+	actualAncestor, actualCycle := c.actualStack[actualVisit]
+
+	switch {
+	case expectedCycle && actualCycle:
+		if expectedAncestor.String() != actualAncestor.String() {
+			c.report(FieldDiff{
+				Path:     path,
+				Expected: CycleRef{Path: expectedAncestor},
+				Actual:   CycleRef{Path: actualAncestor},
+				Change:   Modified,
+			})
+		}
+		return false
+	case expectedCycle:
+		c.report(FieldDiff{
+			Path:     path,
+			Expected: CycleRef{Path: expectedAncestor},
+			Actual:   reportedValue(actual),
+			Change:   Modified,
+		})
+		return false
+	case actualCycle:
+		c.report(FieldDiff{
+			Path:     path,
+			Expected: reportedValue(expected),
+			Actual:   CycleRef{Path: actualAncestor},
+			Change:   Modified,
+		})
+		return false
+	}
+
+	c.expectedStack[expectedVisit] = path
+	c.actualStack[actualVisit] = path
+	return true
+}
+
+// leave undoes a successful enter.
+func (c *comparer) leave(expected, actual reflect.Value) {
+	delete(c.expectedStack, newVisit(expected))
+	delete(c.actualStack, newVisit(actual))
+}
@@ -0,0 +1,111 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type listNode struct {
+	Value int
+	Next  *listNode
+}
+
+type treeNode struct {
+	Name     string
+	Parent   *treeNode
+	Children []*treeNode
+}
+
+func newRing(values ...int) *listNode {
+	head := &listNode{Value: values[0]}
+	current := head
+	for _, v := range values[1:] {
+		current.Next = &listNode{Value: v}
+		current = current.Next
+	}
+	current.Next = head
+	return head
+}
+
+func newTree(root string, children ...string) *treeNode {
+	parent := &treeNode{Name: root}
+	for _, name := range children {
+		parent.Children = append(parent.Children, &treeNode{Name: name, Parent: parent})
+	}
+	return parent
+}
+
+func TestFindDifferences_IdenticalCyclicLists_ShouldTerminateWithoutDifferences(t *testing.T) {
+	// Act
+	diffs := FindDifferences(newRing(1, 2, 3), newRing(1, 2, 3))
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFindDifferences_CyclicListsWithDifferentValues_ShouldReportValue(t *testing.T) {
+	// Act
+	diffs := FindDifferences(newRing(1, 2, 3), newRing(1, 5, 3))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Next.Value", diffs[0].Path.String())
+}
+
+func TestFindDifferences_CyclesOfDifferentLengths_ShouldReportCycleAwarePath(t *testing.T) {
+	// Act
+	diffs := FindDifferences(newRing(1, 1), newRing(1, 1, 1))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Next.Next", diffs[0].Path.String())
+	assert.Equal(t, CycleRef{Path: nil}, diffs[0].Expected)
+	assert.Equal(t, "<cycle to root>", FormatDiffValue(diffs[0].Expected))
+}
+
+func TestFindDifferences_TreeWithParentLinks_ShouldTerminate(t *testing.T) {
+	// Arrange
+	expected := newTree("root", "a", "b")
+	actual := newTree("root", "a", "c")
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Children.[1].Name", diffs[0].Path.String())
+}
+
+func TestFindDifferences_SharedNonCyclicPointers_ShouldBeComparedAtEveryPath(t *testing.T) {
+	// Arrange
+	type pair struct {
+		Left, Right *listNode
+	}
+	shared := &listNode{Value: 1}
+	expected := pair{Left: shared, Right: shared}
+	actual := pair{Left: &listNode{Value: 2}, Right: &listNode{Value: 3}}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 2)
+}
+
+func TestFormatComparisonValue_CyclicValue_ShouldTerminate(t *testing.T) {
+	// Arrange
+	ring := newRing(1, 2)
+	self := map[string]interface{}{}
+	self["self"] = self
+
+	// Act
+	result := FormatComparisonValue(ring)
+	testOutput := FormatTestOutput(ring)
+	mapResult := FormatComparisonValue(self)
+
+	// Assert
+	assert.Equal(t, "{Value: 1, Next: {Value: 2, Next: <cycle>}}", result)
+	assert.Equal(t, "{Value: 1, Next: {Value: 2, Next: <cycle>}}", testOutput)
+	assert.Equal(t, `map["self": <cycle>]`, mapResult)
+}
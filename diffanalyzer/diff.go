@@ -0,0 +1,458 @@
+// Package diffanalyzer compares two values of the same type field by field
+// and reports every difference found, together with the path that leads to it.
+package diffanalyzer
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+)
+
+// FieldDiff describes a single difference found between the expected and the
+// actual value, located by Path (rendered as e.g. "Profile.Address.City" or
+// "Emails.[1]") and classified by Change.
+type FieldDiff struct {
+	Path     Path
+	Expected interface{}
+	Actual   interface{}
+	Change   ChangeType
+	// Tolerance is the tolerance the floats were compared with, the zero
+	// Tolerance for exact comparisons and values other than floats.
+	Tolerance Tolerance
+}
+
+// FindDifferences walks expected and actual recursively and returns every
+// difference found between them. It returns nil when both values are equal.
+func FindDifferences(expected, actual interface{}) []FieldDiff {
+	return FindDifferencesWithOptions(expected, actual)
+}
+
+// FindDifferencesWithOptions is like FindDifferences but applies opts to the
+// comparison. Use NewDiffer to reuse the same options across many calls.
+func FindDifferencesWithOptions(expected, actual interface{}, opts ...Option) []FieldDiff {
+	return NewDiffer(opts...).Compare(expected, actual)
+}
+
+// Differ compares values using a fixed set of options. A Differ is immutable
+// once built and safe for concurrent use.
+type Differ struct {
+	opts options
+}
+
+// NewDiffer builds a Differ configured by opts.
+func NewDiffer(opts ...Option) *Differ {
+	d := &Differ{}
+	for _, opt := range opts {
+		opt(&d.opts)
+	}
+	return d
+}
+
+// Compare returns every difference found between expected and actual.
+// Values Diff would refuse are left out silently.
+func (d *Differ) Compare(expected, actual interface{}) []FieldDiff {
+	diffs, _ := d.Diff(expected, actual)
+	return diffs
+}
+
+// Diff is like Compare but also returns an *UnsupportedKindError when some
+// values could not be compared, e.g. funcs under WithStrictKinds. The
+// differences found elsewhere are returned in any case.
+func (d *Differ) Diff(expected, actual interface{}) ([]FieldDiff, error) {
+	c := &comparer{
+		opts:          &d.opts,
+		expectedStack: make(map[visit]Path),
+		actualStack:   make(map[visit]Path),
+		unsupported:   &UnsupportedKindError{},
+		patterns:      make(map[string]*regexp.Regexp),
+	}
+	c.compare(reflect.ValueOf(expected), reflect.ValueOf(actual), nil, fieldTag{})
+	if len(c.unsupported.Paths) > 0 {
+		return c.diffs, c.unsupported
+	}
+	return c.diffs, nil
+}
+
+// comparer holds the state of a single comparison run.
+type comparer struct {
+	opts  *options
+	diffs []FieldDiff
+	// expectedStack and actualStack hold the pointers, maps and slices
+	// currently being traversed on each side, with the path where they were
+	// entered, to detect cycles.
+	expectedStack map[visit]Path
+	actualStack   map[visit]Path
+	// unsupported collects the values that could not be compared.
+	unsupported *UnsupportedKindError
+	// patterns caches the expressions compiled under WithRegexpMatch.
+	patterns map[string]*regexp.Regexp
+}
+
+// compare records every difference between expected and actual found at and
+// below path. tag holds the `diff` tag of the struct field the values belong
+// to, if any.
+func (c *comparer) compare(expectedValue, actualValue reflect.Value, path Path, tag fieldTag) {
+	if c.ignored(path) {
+		return
+	}
+
+	if !expectedValue.IsValid() || !actualValue.IsValid() {
+		// Only untyped nils, as passed to FindDifferences, are invalid.
+		if expectedValue.IsValid() != actualValue.IsValid() {
+			c.report(FieldDiff{
+				Path:     path,
+				Expected: reportedValue(expectedValue),
+				Actual:   reportedValue(actualValue),
+				Change:   presenceChange(expectedValue.IsValid()),
+			})
+		}
+		return
+	}
+
+	if c.dontCare(expectedValue, path, tag) {
+		return
+	}
+
+	if expectedValue.Type() != actualValue.Type() && !c.sameShape(expectedValue.Type(), actualValue.Type()) {
+		c.report(FieldDiff{
+			Path:     path,
+			Expected: expectedValue.Type().String(),
+			Actual:   actualValue.Type().String(),
+			Change:   TypeChanged,
+		})
+		return
+	}
+
+	if c.compareCustom(expectedValue, actualValue, path) ||
+		c.compareTime(expectedValue, actualValue, path, tag) ||
+		c.compareMethods(expectedValue, actualValue, path, tag) {
+		return
+	}
+
+	switch expectedValue.Kind() {
+	case reflect.Struct:
+		c.compareStruct(expectedValue, actualValue, path)
+
+	case reflect.Interface:
+		c.compareInterface(expectedValue, actualValue, path, tag)
+
+	case reflect.String:
+		c.compareStrings(expectedValue, actualValue, path, tag)
+
+	case reflect.Bool:
+		if expectedValue.Bool() != actualValue.Bool() {
+			c.report(FieldDiff{
+				Path:     path,
+				Expected: expectedValue.Bool(),
+				Actual:   actualValue.Bool(),
+				Change:   Modified,
+			})
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if expectedValue.Int() != actualValue.Int() {
+			c.report(FieldDiff{
+				Path:     path,
+				Expected: reportedValue(expectedValue),
+				Actual:   reportedValue(actualValue),
+				Change:   Modified,
+			})
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if expectedValue.Uint() != actualValue.Uint() {
+			c.report(FieldDiff{
+				Path:     path,
+				Expected: reportedValue(expectedValue),
+				Actual:   reportedValue(actualValue),
+				Change:   Modified,
+			})
+		}
+
+	case reflect.Float32, reflect.Float64:
+		node := c.nodeOptions(path, expectedValue.Type(), tag)
+		bitSize := expectedValue.Type().Bits()
+		if !floatsEqual(expectedValue.Float(), actualValue.Float(), bitSize, node.tolerance, node.nanEqual) {
+			c.report(FieldDiff{
+				Path:      path,
+				Expected:  reportedValue(expectedValue),
+				Actual:    reportedValue(actualValue),
+				Change:    Modified,
+				Tolerance: node.tolerance,
+			})
+		}
+
+	case reflect.Complex64, reflect.Complex128:
+		if expectedValue.Complex() != actualValue.Complex() {
+			c.report(FieldDiff{
+				Path:     path,
+				Expected: reportedValue(expectedValue),
+				Actual:   reportedValue(actualValue),
+				Change:   Modified,
+			})
+		}
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		c.compareIdentity(expectedValue, actualValue, path)
+
+	case reflect.Ptr:
+		if expectedValue.IsNil() != actualValue.IsNil() {
+			if c.nilEquivalent(expectedValue, actualValue, path, tag) {
+				return
+			}
+			c.report(FieldDiff{
+				Path:     path,
+				Expected: reportedValue(expectedValue),
+				Actual:   reportedValue(actualValue),
+				Change:   nilChange(expectedValue, actualValue),
+			})
+			return
+		}
+		if expectedValue.IsNil() || !c.enter(expectedValue, actualValue, path) {
+			return
+		}
+		defer c.leave(expectedValue, actualValue)
+
+		c.compare(expectedValue.Elem(), actualValue.Elem(), path.Deref(), tag)
+
+	case reflect.Array:
+		if isByteSequence(expectedValue.Type()) && c.nodeOptions(path, expectedValue.Type(), tag).compactBytes {
+			c.compareBytes(expectedValue, actualValue, path)
+			return
+		}
+
+		for i := range expectedValue.Len() {
+			c.compare(expectedValue.Index(i), actualValue.Index(i), path.Index(i), tag)
+		}
+
+	case reflect.Slice:
+		if expectedValue.IsNil() != actualValue.IsNil() {
+			if c.nilEquivalent(expectedValue, actualValue, path, tag) {
+				return
+			}
+			c.report(FieldDiff{
+				Path:     path,
+				Expected: reportedValue(expectedValue),
+				Actual:   reportedValue(actualValue),
+				Change:   nilChange(expectedValue, actualValue),
+			})
+			return
+		}
+
+		if expectedValue.Len() == 0 && actualValue.Len() == 0 {
+			return
+		}
+
+		if !c.enter(expectedValue, actualValue, path) {
+			return
+		}
+		defer c.leave(expectedValue, actualValue)
+
+		node := c.nodeOptions(path, expectedValue.Type(), tag)
+		if isByteSequence(expectedValue.Type()) && node.compactBytes {
+			c.compareBytes(expectedValue, actualValue, path)
+			return
+		}
+
+		if key, ok := c.sliceKey(expectedValue.Type().Elem()); ok && expectedValue.Type() == actualValue.Type() {
+			c.compareKeyed(expectedValue, actualValue, path, key, tag)
+			return
+		}
+
+		if node.unordered {
+			c.compareUnordered(expectedValue, actualValue, path, tag)
+			return
+		}
+
+		c.compareSequence(expectedValue, actualValue, path, tag)
+
+	case reflect.Map:
+		if expectedValue.IsNil() != actualValue.IsNil() {
+			if c.nilEquivalent(expectedValue, actualValue, path, tag) {
+				return
+			}
+			c.report(FieldDiff{
+				Path:     path,
+				Expected: reportedValue(expectedValue),
+				Actual:   reportedValue(actualValue),
+				Change:   nilChange(expectedValue, actualValue),
+			})
+			return
+		}
+
+		if expectedValue.IsNil() || !c.enter(expectedValue, actualValue, path) {
+			return
+		}
+		defer c.leave(expectedValue, actualValue)
+
+		for _, entry := range sortedMapEntries(expectedValue) {
+			keyPath := path.MapKey(reportedValue(entry.key))
+			actualVal := actualValue.MapIndex(entry.key)
+			if !actualVal.IsValid() {
+				c.report(FieldDiff{
+					Path:     keyPath,
+					Expected: reportedValue(entry.value),
+					Actual:   nil,
+					Change:   Removed,
+				})
+				continue
+			}
+
+			c.compare(entry.value, actualVal, keyPath, tag)
+		}
+
+		if c.partial(path, expectedValue.Type(), tag) {
+			return
+		}
+		for _, entry := range sortedMapEntries(actualValue) {
+			if expectedValue.MapIndex(entry.key).IsValid() {
+				continue
+			}
+			c.report(FieldDiff{
+				Path:     path.MapKey(reportedValue(entry.key)),
+				Expected: nil,
+				Actual:   reportedValue(entry.value),
+				Change:   Added,
+			})
+		}
+
+	default:
+		c.refuse(expectedValue.Kind(), path)
+	}
+}
+
+// compareStruct compares two structs field by field, honoring the `diff`
+// tags, the field naming and the unexported field policy.
+func (c *comparer) compareStruct(expected, actual reflect.Value, path Path) {
+	typeOfT := expected.Type()
+	if typeOfT != actual.Type() {
+		c.compareStructsByName(expected, actual, path)
+		return
+	}
+
+	if c.opts.unexported.isOpaque(typeOfT) {
+		if !deepEqual(expected, actual) {
+			c.report(FieldDiff{
+				Path:     path,
+				Expected: reportedValue(expected),
+				Actual:   reportedValue(actual),
+				Change:   Modified,
+			})
+		}
+		return
+	}
+
+	for _, field := range c.comparedFields(typeOfT) {
+		c.compareField(expected.Field(field.index), actual.Field(field.index), path, field)
+	}
+}
+
+// structField is a struct field that takes part in the comparison.
+type structField struct {
+	index   int
+	tag     fieldTag
+	encoded encodedField
+}
+
+// comparedFields lists the fields of the struct type t that are compared
+// under the current options.
+func (c *comparer) comparedFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !c.opts.unexported.comparesField(field) {
+			continue
+		}
+		tag := parseFieldTag(field)
+		encoded := c.opts.naming.encode(field, tag)
+		if encoded.skip {
+			continue
+		}
+		fields = append(fields, structField{index: i, tag: tag, encoded: encoded})
+	}
+	return fields
+}
+
+// compareField compares the values of field in two structs found at path.
+func (c *comparer) compareField(expected, actual reflect.Value, path Path, field structField) {
+	if field.encoded.omitEmpty && isEmptyValue(expected) && isEmptyValue(actual) {
+		return
+	}
+	if !field.encoded.inline {
+		path = path.Field(field.encoded.name)
+	}
+	c.compare(expected, actual, path, field.tag)
+}
+
+// compareInterface compares two interface values: a nil and a non-nil
+// interface are reported as added or removed, interfaces holding unrelated
+// dynamic types as a type change naming both types, and otherwise the
+// dynamic values are compared.
+func (c *comparer) compareInterface(expected, actual reflect.Value, path Path, tag fieldTag) {
+	if expected.IsNil() || actual.IsNil() {
+		if expected.IsNil() != actual.IsNil() {
+			c.report(FieldDiff{
+				Path:     path,
+				Expected: reportedValue(expected),
+				Actual:   reportedValue(actual),
+				Change:   presenceChange(!expected.IsNil()),
+			})
+		}
+		return
+	}
+
+	if expected.Elem().Type() != actual.Elem().Type() && !c.sameShape(expected.Elem().Type(), actual.Elem().Type()) {
+		c.report(FieldDiff{
+			Path:     path,
+			Expected: expected.Elem().Type().String(),
+			Actual:   actual.Elem().Type().String(),
+			Change:   TypeChanged,
+		})
+		return
+	}
+
+	c.compare(expected.Elem(), actual.Elem(), path, tag)
+}
+
+// equal reports whether expected and actual compare equal under the same
+// options, without recording any difference. It tries out a pairing, so the
+// values it refuses are only recorded when they turn out equal and the
+// pairing is kept.
+func (c *comparer) equal(expected, actual reflect.Value, path Path, tag fieldTag) bool {
+	if deepEqual(expected, actual) {
+		return true
+	}
+	sub := &comparer{
+		opts:          c.opts,
+		expectedStack: c.expectedStack,
+		actualStack:   c.actualStack,
+		unsupported:   &UnsupportedKindError{},
+		patterns:      c.patterns,
+	}
+	sub.compare(expected, actual, path, tag)
+	if len(sub.diffs) > 0 {
+		return false
+	}
+	c.unsupported.merge(sub.unsupported)
+	return true
+}
+
+// mapEntry is a key of a map together with its value.
+type mapEntry struct {
+	key, value reflect.Value
+}
+
+// sortedMapEntries returns the entries of m ordered by the printed form of
+// their keys, so that map differences are reported in a stable order. Values
+// are read while iterating, as keys such as NaN cannot be looked up.
+func sortedMapEntries(m reflect.Value) []mapEntry {
+	entries := make([]mapEntry, 0, m.Len())
+	for iter := m.MapRange(); iter.Next(); {
+		entries = append(entries, mapEntry{key: iter.Key(), value: iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return fmt.Sprint(entries[i].key) < fmt.Sprint(entries[j].key)
+	})
+	return entries
+}
@@ -1,6 +1,7 @@
-package main
+package diffanalyzer
 
 import (
+	"math"
 	"testing"
 
 	"github.com/seu-usuario/meu-projeto/models"
@@ -61,7 +62,7 @@ func TestFindDifferences_DifferentStringFields_ShouldReturnCorrectDifferences(t
 
 	// Assert
 	assert.Len(t, diffs, 1, "Should find exactly one difference")
-	assert.Equal(t, "Name", diffs[0].Path)
+	assert.Equal(t, "Name", diffs[0].Path.String())
 	assert.Equal(t, "Alice", diffs[0].Expected)
 	assert.Equal(t, "Bob", diffs[0].Actual)
 }
@@ -83,7 +84,7 @@ func TestFindDifferences_DifferentIntegerFields_ShouldReturnCorrectDifferences(t
 
 	// Assert
 	assert.Len(t, diffs, 1, "Should find exactly one difference")
-	assert.Equal(t, "ID", diffs[0].Path)
+	assert.Equal(t, "ID", diffs[0].Path.String())
 	assert.Equal(t, 1, diffs[0].Expected)
 	assert.Equal(t, 2, diffs[0].Actual)
 }
@@ -122,7 +123,7 @@ func TestFindDifferences_DifferentNestedStructFields_ShouldReturnCorrectPath(t *
 
 	pathsFound := make(map[string]bool)
 	for _, diff := range diffs {
-		pathsFound[diff.Path] = true
+		pathsFound[diff.Path.String()] = true
 	}
 
 	assert.True(t, pathsFound["Profile.Bio"], "Should find difference in Profile.Bio")
@@ -160,10 +161,10 @@ func TestFindDifferences_DifferentSliceElements_ShouldReturnDifferences(t *testi
 	foundTagDiff := false
 
 	for _, diff := range diffs {
-		if diff.Path == "Emails.[1]" {
+		if diff.Path.String() == "Emails.[1]" {
 			foundEmailDiff = true
 		}
-		if diff.Path == "Profile.Tags.[1]" {
+		if diff.Path.String() == "Profile.Tags.[1]" {
 			foundTagDiff = true
 		}
 	}
@@ -228,9 +229,9 @@ func TestFindDifferences_ComplexNestedStructure_ShouldFindAllDifferences(t *test
 	// Assert
 	assert.NotEmpty(t, diffs, "Should find multiple differences")
 
-	pathsFound := make(map[string]models.FieldDiff)
+	pathsFound := make(map[string]FieldDiff)
 	for _, diff := range diffs {
-		pathsFound[diff.Path] = diff
+		pathsFound[diff.Path.String()] = diff
 	}
 
 	if nameDiff, exists := pathsFound["Name"]; exists {
@@ -278,7 +279,7 @@ func TestFindDifferences_SimplePersonaStruct_ShouldWork(t *testing.T) {
 
 	pathsFound := make(map[string]bool)
 	for _, diff := range diffs {
-		pathsFound[diff.Path] = true
+		pathsFound[diff.Path.String()] = true
 	}
 
 	assert.True(t, pathsFound["Nome"] || pathsFound["Idade"] || pathsFound["Ativo"],
@@ -304,7 +305,7 @@ func TestFindDifferences_DifferentBoolFields_ShouldReturnCorrectDifferences(t *t
 
 	// Assert
 	assert.Len(t, diffs, 1, "Should find exactly one difference")
-	assert.Equal(t, "Ativo", diffs[0].Path)
+	assert.Equal(t, "Ativo", diffs[0].Path.String())
 	assert.Equal(t, true, diffs[0].Expected)
 	assert.Equal(t, false, diffs[0].Actual)
 }
@@ -328,7 +329,121 @@ func TestFindDifferences_DifferentSliceLengths_ShouldReturnDifferences(t *testin
 
 	// Assert
 	assert.Len(t, diffs, 1, "Should find exactly one difference for different slice lengths")
-	assert.Equal(t, "Emails", diffs[0].Path)
+	assert.Equal(t, "Emails.[1]", diffs[0].Path.String())
+	assert.Equal(t, Removed, diffs[0].Change)
+	assert.Equal(t, "alice@personal.com", diffs[0].Expected)
+}
+
+func TestFindDifferences_ElementAppended_ShouldReportSingleAddedElement(t *testing.T) {
+	// Arrange
+	expected := models.ItemCollection{}
+	for i := range 500 {
+		expected.Items = append(expected.Items, models.Item{ID: i, Status: "active", Value: i * 10})
+	}
+
+	actual := models.ItemCollection{Items: append([]models.Item{}, expected.Items...)}
+	actual.Items = append(actual.Items, models.Item{ID: 500, Status: "pending", Value: 5000})
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 1, "Appending one element should yield one difference")
+	assert.Equal(t, "Items.[500]", diffs[0].Path.String())
+	assert.Equal(t, Added, diffs[0].Change)
+	assert.Nil(t, diffs[0].Expected)
+	assert.Equal(t, models.Item{ID: 500, Status: "pending", Value: 5000}, diffs[0].Actual)
+}
+
+func TestFindDifferences_ElementInsertedAtFront_ShouldNotShiftOtherElements(t *testing.T) {
+	// Arrange
+	expected := []models.Item{
+		{ID: 1, Status: "active", Value: 100},
+		{ID: 3, Status: "active", Value: 150},
+	}
+
+	actual := []models.Item{
+		{ID: 0, Status: "new", Value: 0},
+		{ID: 1, Status: "active", Value: 100},
+		{ID: 3, Status: "active", Value: 150},
+	}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "[0]", diffs[0].Path.String())
+	assert.Equal(t, Added, diffs[0].Change)
+}
+
+func TestFindDifferences_ElementReplacedAndAppended_ShouldReportModificationAndAddition(t *testing.T) {
+	// Arrange
+	person1 := models.Person{Emails: []string{"a@x.com", "b@x.com", "c@x.com"}}
+	person2 := models.Person{Emails: []string{"a@x.com", "B@x.com", "c@x.com", "d@x.com"}}
+
+	// Act
+	diffs := FindDifferences(person1, person2)
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "Emails.[1]", diffs[0].Path.String())
+	assert.Equal(t, Modified, diffs[0].Change)
+	assert.Equal(t, "b@x.com", diffs[0].Expected)
+	assert.Equal(t, "B@x.com", diffs[0].Actual)
+	assert.Equal(t, "Emails.[3]", diffs[1].Path.String())
+	assert.Equal(t, Added, diffs[1].Change)
+}
+
+func TestFindDifferences_ElementDroppedAndAppended_ShouldNotShiftOtherElements(t *testing.T) {
+	// Arrange
+	expected := []string{"a", "b", "c", "d"}
+	actual := []string{"b", "c", "d", "e"}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "[0]", diffs[0].Path.String())
+	assert.Equal(t, Removed, diffs[0].Change)
+	assert.Equal(t, "[3]", diffs[1].Path.String())
+	assert.Equal(t, Added, diffs[1].Change)
+}
+
+func TestFindDifferences_LargeSlicesOfSameLength_ShouldPairChangedElementsByPosition(t *testing.T) {
+	// Arrange
+	var expected, actual []models.Item
+	for i := range 4000 {
+		expected = append(expected, models.Item{ID: i, Status: "active", Value: i})
+		actual = append(actual, models.Item{ID: i, Status: "inactive", Value: i})
+	}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 4000)
+	assert.Equal(t, "[3999].Status", diffs[3999].Path.String())
+}
+
+func TestFindDifferences_LargeSlicesOfDifferentLengths_ShouldStayLinear(t *testing.T) {
+	// Arrange
+	var expected, actual []models.Item
+	for i := range 4000 {
+		expected = append(expected, models.Item{ID: i, Status: "active", Value: i})
+		actual = append(actual, models.Item{ID: i, Status: "inactive", Value: i})
+	}
+	actual = append(actual, models.Item{ID: 4000})
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 4001)
+	assert.Equal(t, "[0].Status", diffs[0].Path.String())
+	assert.Equal(t, "[4000]", diffs[4000].Path.String())
+	assert.Equal(t, Added, diffs[4000].Change)
 }
 
 func TestFindDifferences_ZeroValues_ShouldDetectCorrectly(t *testing.T) {
@@ -353,7 +468,7 @@ func TestFindDifferences_ZeroValues_ShouldDetectCorrectly(t *testing.T) {
 
 	pathsFound := make(map[string]bool)
 	for _, diff := range diffs {
-		pathsFound[diff.Path] = true
+		pathsFound[diff.Path.String()] = true
 	}
 
 	assert.True(t, pathsFound["Nome"], "Should find difference in Nome")
@@ -387,7 +502,7 @@ func TestFindDifferences_SliceElementPath_ShouldShowCorrectPath(t *testing.T) {
 
 	foundTagDiff := false
 	for _, diff := range diffs {
-		if diff.Path == "Profile.Tags.[1]" {
+		if diff.Path.String() == "Profile.Tags.[1]" {
 			foundTagDiff = true
 			assert.Equal(t, "backend", diff.Expected)
 			assert.Equal(t, "frontend", diff.Actual)
@@ -438,7 +553,7 @@ func TestFindDifferences_NestedEmptyVsFilledStruct_ShouldDetectDifferences(t *te
 
 	pathsFound := make(map[string]bool)
 	for _, diff := range diffs {
-		pathsFound[diff.Path] = true
+		pathsFound[diff.Path.String()] = true
 	}
 
 	assert.True(t, pathsFound["Profile.Address.City"], "Should find difference in nested City")
@@ -471,9 +586,9 @@ func TestFindDifferences_AllIntegerTypes_ShouldDetectCorrectly(t *testing.T) {
 	// Assert
 	assert.Len(t, diffs, 5, "Should find differences in all integer fields")
 
-	pathsFound := make(map[string]models.FieldDiff)
+	pathsFound := make(map[string]FieldDiff)
 	for _, diff := range diffs {
-		pathsFound[diff.Path] = diff
+		pathsFound[diff.Path.String()] = diff
 	}
 
 	// Verify specific types are preserved
@@ -514,9 +629,9 @@ func TestFindDifferences_UnsignedIntegerTypes_ShouldDetectCorrectly(t *testing.T
 	// Assert
 	assert.Len(t, diffs, 5, "Should find differences in all unsigned integer fields")
 
-	pathsFound := make(map[string]models.FieldDiff)
+	pathsFound := make(map[string]FieldDiff)
 	for _, diff := range diffs {
-		pathsFound[diff.Path] = diff
+		pathsFound[diff.Path.String()] = diff
 	}
 
 	if uintDiff, exists := pathsFound["UintValue"]; exists {
@@ -544,9 +659,9 @@ func TestFindDifferences_FloatTypes_ShouldDetectCorrectly(t *testing.T) {
 	// Assert
 	assert.Len(t, diffs, 2, "Should find differences in float fields")
 
-	pathsFound := make(map[string]models.FieldDiff)
+	pathsFound := make(map[string]FieldDiff)
 	for _, diff := range diffs {
-		pathsFound[diff.Path] = diff
+		pathsFound[diff.Path.String()] = diff
 	}
 
 	if float32Diff, exists := pathsFound["Float32Value"]; exists {
@@ -606,7 +721,7 @@ func TestFindDifferences_SimpleStringMaps_ShouldDetectCorrectly(t *testing.T) {
 
 	// Assert
 	assert.Len(t, diffs, 1, "Should find exactly one difference in map")
-	assert.Equal(t, "StringMap.[key1]", diffs[0].Path)
+	assert.Equal(t, "StringMap.[key1]", diffs[0].Path.String())
 	assert.Equal(t, "value1", diffs[0].Expected)
 	assert.Equal(t, "value1_modified", diffs[0].Actual)
 }
@@ -631,8 +746,13 @@ func TestFindDifferences_MapsDifferentKeys_ShouldDetectCorrectly(t *testing.T) {
 	diffs := FindDifferences(container1, container2)
 
 	// Assert
-	assert.Len(t, diffs, 1, "Should detect maps with different keys as different")
-	assert.Equal(t, "StringMap.[key2]", diffs[0].Path)
+	assert.Len(t, diffs, 2, "Should report the missing and the extra key")
+	assert.Equal(t, "StringMap.[key2]", diffs[0].Path.String())
+	assert.Equal(t, Removed, diffs[0].Change)
+	assert.Equal(t, "StringMap.[key3]", diffs[1].Path.String())
+	assert.Equal(t, Added, diffs[1].Change)
+	assert.Nil(t, diffs[1].Expected)
+	assert.Equal(t, "value3", diffs[1].Actual)
 }
 
 func TestFindDifferences_NilVsEmptyMap_ShouldDetectDifference(t *testing.T) {
@@ -650,7 +770,7 @@ func TestFindDifferences_NilVsEmptyMap_ShouldDetectDifference(t *testing.T) {
 
 	// Assert
 	assert.Len(t, diffs, 1, "Should detect difference between nil and empty map")
-	assert.Equal(t, "StringMap", diffs[0].Path)
+	assert.Equal(t, "StringMap", diffs[0].Path.String())
 }
 
 func TestFindDifferences_NestedMaps_ShouldDetectCorrectly(t *testing.T) {
@@ -678,7 +798,7 @@ func TestFindDifferences_NestedMaps_ShouldDetectCorrectly(t *testing.T) {
 
 	// Assert
 	assert.Len(t, diffs, 1, "Should find difference in nested map")
-	assert.Equal(t, "NestedMap.[group1].[item1]", diffs[0].Path)
+	assert.Equal(t, "NestedMap.[group1].[item1]", diffs[0].Path.String())
 	assert.Equal(t, 10, diffs[0].Expected)
 	assert.Equal(t, 15, diffs[0].Actual)
 }
@@ -708,7 +828,7 @@ func TestFindDifferences_MapWithComplexValues_ShouldDetectCorrectly(t *testing.T
 
 	// Assert
 	assert.Len(t, diffs, 1, "Should find difference in complex map value")
-	assert.Equal(t, "PersonMap.[person1].Name", diffs[0].Path)
+	assert.Equal(t, "PersonMap.[person1].Name", diffs[0].Path.String())
 	assert.Equal(t, "Alice", diffs[0].Expected)
 	assert.Equal(t, "Bob", diffs[0].Actual)
 }
@@ -731,3 +851,170 @@ func TestFindDifferences_EmptyMaps_ShouldReturnNoDifferences(t *testing.T) {
 	// Assert
 	assert.Empty(t, diffs, "Empty maps should have no differences")
 }
+
+func TestFindDifferences_MapWithExtraKey_ShouldReportAddedKey(t *testing.T) {
+	// Arrange
+	container1 := models.MapContainer{
+		IntMap: map[string]int{"count": 10},
+	}
+
+	container2 := models.MapContainer{
+		IntMap: map[string]int{"count": 10, "total": 100},
+	}
+
+	// Act
+	diffs := FindDifferences(container1, container2)
+
+	// Assert
+	assert.Len(t, diffs, 1, "Should report only the extra key, not the whole map")
+	assert.Equal(t, "IntMap.[total]", diffs[0].Path.String())
+	assert.Equal(t, Added, diffs[0].Change)
+	assert.Equal(t, 100, diffs[0].Actual)
+}
+
+func TestFindDifferences_MapsDifferentLengths_ShouldDiffSharedKeysRecursively(t *testing.T) {
+	// Arrange
+	container1 := models.MapContainer{
+		StringMap: map[string]string{
+			"key1": "value1",
+			"key2": "value2",
+			"key3": "value3",
+		},
+	}
+
+	container2 := models.MapContainer{
+		StringMap: map[string]string{
+			"key1": "value1_modified",
+			"key2": "value2",
+		},
+	}
+
+	// Act
+	diffs := FindDifferences(container1, container2)
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "StringMap.[key1]", diffs[0].Path.String())
+	assert.Equal(t, Modified, diffs[0].Change)
+	assert.Equal(t, "StringMap.[key3]", diffs[1].Path.String())
+	assert.Equal(t, Removed, diffs[1].Change)
+}
+
+// ===== TESTS FOR OPTIONS =====
+
+func TestFindDifferencesWithOptions_NoOptions_ShouldMatchFindDifferences(t *testing.T) {
+	// Arrange
+	person1 := models.Person{ID: 1, Name: "Alice", Emails: []string{"alice@company.com"}}
+	person2 := models.Person{ID: 2, Name: "Alice", Emails: []string{"alice@gmail.com"}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(person1, person2)
+
+	// Assert
+	assert.Equal(t, FindDifferences(person1, person2), diffs)
+}
+
+func TestDiffer_ReusedAcrossComparisons_ShouldNotLeakState(t *testing.T) {
+	// Arrange
+	differ := NewDiffer()
+
+	// Act
+	first := differ.Compare(models.Address{City: "São Paulo"}, models.Address{City: "Rio"})
+	second := differ.Compare(models.Address{City: "São Paulo"}, models.Address{City: "São Paulo"})
+
+	// Assert
+	assert.Len(t, first, 1)
+	assert.Empty(t, second, "A Differ should start every comparison from scratch")
+}
+
+// ===== TESTS FOR CHANGE TYPES =====
+
+func TestFindDifferences_ModifiedValue_ShouldBeClassifiedAsModified(t *testing.T) {
+	// Arrange
+	person1 := models.Person{ID: 1, Name: "Alice"}
+	person2 := models.Person{ID: 1, Name: "Bob"}
+
+	// Act
+	diffs := FindDifferences(person1, person2)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, Modified, diffs[0].Change)
+}
+
+func TestFindDifferences_MissingMapKey_ShouldBeClassifiedAsRemoved(t *testing.T) {
+	// Arrange
+	container1 := models.MapContainer{StringMap: map[string]string{"key1": "value1", "key2": "value2"}}
+	container2 := models.MapContainer{StringMap: map[string]string{"key1": "value1"}}
+
+	// Act
+	diffs := FindDifferences(container1, container2)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, Removed, diffs[0].Change)
+}
+
+func TestFindDifferences_NilVsEmptySlice_ShouldBeClassifiedAsNilVsEmpty(t *testing.T) {
+	// Arrange
+	person1 := models.Person{Emails: nil}
+	person2 := models.Person{Emails: []string{}}
+
+	// Act
+	diffs := FindDifferences(person1, person2)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Emails", diffs[0].Path.String())
+	assert.Equal(t, NilVsEmpty, diffs[0].Change)
+}
+
+func TestFindDifferences_NilVsFilledSlice_ShouldBeClassifiedAsAddedOrRemoved(t *testing.T) {
+	// Arrange
+	withoutEmails := models.Person{Emails: nil}
+	withEmails := models.Person{Emails: []string{"alice@company.com"}}
+
+	// Act
+	added := FindDifferences(withoutEmails, withEmails)
+	removed := FindDifferences(withEmails, withoutEmails)
+
+	// Assert
+	assert.Len(t, added, 1)
+	assert.Equal(t, Added, added[0].Change)
+	assert.Len(t, removed, 1)
+	assert.Equal(t, Removed, removed[0].Change)
+}
+
+func TestFindDifferences_DifferentKinds_ShouldBeClassifiedAsTypeChanged(t *testing.T) {
+	// Act
+	diffs := FindDifferences(42, "42")
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, TypeChanged, diffs[0].Change)
+}
+
+func TestChangeType_String_ShouldReturnReadableName(t *testing.T) {
+	assert.Equal(t, "modified", Modified.String())
+	assert.Equal(t, "added", Added.String())
+	assert.Equal(t, "removed", Removed.String())
+	assert.Equal(t, "type-changed", TypeChanged.String())
+	assert.Equal(t, "nil-vs-empty", NilVsEmpty.String())
+}
+
+func TestFindDifferences_NaNMapKeys_ShouldReportRemovedAndAdded(t *testing.T) {
+	// Arrange
+	expected := map[float64]int{math.NaN(): 1, 2: 2}
+	actual := map[float64]int{math.NaN(): 1, 2: 2}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 2, "A NaN key never matches itself")
+	assert.Equal(t, Removed, diffs[0].Change)
+	assert.Equal(t, 1, diffs[0].Expected)
+	assert.Equal(t, Added, diffs[1].Change)
+	assert.Equal(t, 1, diffs[1].Actual)
+	assert.Equal(t, "map[2: 2, NaN: 1]", FormatComparisonValue(expected))
+}
@@ -0,0 +1,34 @@
+package diffanalyzer
+
+import "reflect"
+
+// WithNilAsEmpty treats a nil slice or map as equal to an empty one, as
+// JSON round trips do not preserve the difference. By default the two are
+// reported as NilVsEmpty.
+func WithNilAsEmpty() Option {
+	return func(o *options) {
+		o.node.nilAsEmpty = true
+	}
+}
+
+// WithNilPointerAsZero treats a nil pointer as equal to a pointer to the zero
+// value of its type.
+func WithNilPointerAsZero() Option {
+	return func(o *options) {
+		o.node.nilPointerAsZero = true
+	}
+}
+
+// nilEquivalent reports whether expected and actual, pointers, slices or maps
+// of which exactly one is nil, are equal under the options of their node.
+func (c *comparer) nilEquivalent(expected, actual reflect.Value, path Path, tag fieldTag) bool {
+	node := c.nodeOptions(path, expected.Type(), tag)
+	other := actual
+	if actual.IsNil() {
+		other = expected
+	}
+	if other.Kind() == reflect.Ptr {
+		return node.nilPointerAsZero && other.Elem().IsZero()
+	}
+	return node.nilAsEmpty && other.Len() == 0
+}
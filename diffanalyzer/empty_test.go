@@ -0,0 +1,89 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/seu-usuario/meu-projeto/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type shipment struct {
+	Tracking *string
+	Address  *models.Address
+	Tags     []string
+}
+
+func TestFindDifferences_NilVsEmptyByDefault_ShouldReportNilVsEmpty(t *testing.T) {
+	// Arrange
+	expected := models.MapContainer{StringMap: nil}
+	actual := models.MapContainer{StringMap: map[string]string{}}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, NilVsEmpty, diffs[0].Change)
+}
+
+func TestFindDifferences_WithNilAsEmpty_ShouldTreatNilAndEmptyAsEqual(t *testing.T) {
+	// Arrange
+	expected := models.MapContainer{
+		StringMap: nil,
+		IntMap:    map[string]int{},
+		PersonMap: map[string]models.Person{"alice": {Emails: []string{}}},
+	}
+	actual := models.MapContainer{
+		StringMap: map[string]string{},
+		IntMap:    nil,
+		PersonMap: map[string]models.Person{"alice": {Emails: nil}},
+	}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithNilAsEmpty())
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFindDifferences_WithNilAsEmpty_ShouldStillReportNonEmptyValues(t *testing.T) {
+	// Act
+	diffs := FindDifferencesWithOptions(shipment{}, shipment{Tags: []string{"fragile"}}, WithNilAsEmpty())
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Tags", diffs[0].Path.String())
+	assert.Equal(t, Added, diffs[0].Change)
+}
+
+func TestFindDifferences_WithNilAsEmptyForPath_ShouldOnlyApplyToPath(t *testing.T) {
+	// Arrange
+	expected := models.MapContainer{StringMap: nil, IntMap: nil}
+	actual := models.MapContainer{StringMap: map[string]string{}, IntMap: map[string]int{}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, ForPath("IntMap", WithNilAsEmpty()))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "StringMap", diffs[0].Path.String())
+}
+
+func TestFindDifferences_WithNilPointerAsZero_ShouldTreatPointerToZeroAsNil(t *testing.T) {
+	// Arrange
+	empty := ""
+	code := "BR123"
+	expected := shipment{}
+	actual := shipment{Tracking: &empty, Address: &models.Address{}}
+
+	// Act
+	lenient := FindDifferencesWithOptions(expected, actual, WithNilPointerAsZero())
+	strict := FindDifferences(expected, actual)
+	nonZero := FindDifferencesWithOptions(expected, shipment{Tracking: &code}, WithNilPointerAsZero())
+
+	// Assert
+	assert.Empty(t, lenient)
+	assert.Len(t, strict, 2)
+	assert.Len(t, nonZero, 1)
+	assert.Equal(t, "Tracking", nonZero[0].Path.String())
+}
@@ -0,0 +1,97 @@
+package diffanalyzer
+
+import "math"
+
+// Tolerance describes how far apart two floats may be and still be considered
+// equal. Two floats are equal when they are within any of the non-zero
+// bounds; the zero Tolerance requires them to be exactly equal.
+type Tolerance struct {
+	// Absolute is the largest absolute difference allowed.
+	Absolute float64
+	// Relative is the largest difference allowed as a fraction of the larger
+	// magnitude of the two values, e.g. 1e-9.
+	Relative float64
+	// ULPs is the largest number of representable floats (units in the last
+	// place) allowed between the two values, counted in the precision of
+	// their type.
+	ULPs uint64
+}
+
+// WithAbsoluteTolerance treats floats that differ by no more than epsilon as
+// equal. Like the other tolerances it can be set globally or through ForPath
+// and ForType, and per field with the tag `diff:",tolerance=0.01"`.
+func WithAbsoluteTolerance(epsilon float64) Option {
+	return func(o *options) {
+		o.node.tolerance.Absolute = epsilon
+	}
+}
+
+// WithRelativeTolerance treats floats whose difference is no more than
+// fraction of the larger of their magnitudes as equal.
+func WithRelativeTolerance(fraction float64) Option {
+	return func(o *options) {
+		o.node.tolerance.Relative = fraction
+	}
+}
+
+// WithULPTolerance treats floats with no more than ulps representable values
+// between them as equal.
+func WithULPTolerance(ulps uint64) Option {
+	return func(o *options) {
+		o.node.tolerance.ULPs = ulps
+	}
+}
+
+// WithNaNEqual treats NaN as equal to NaN. By default NaN differs from every
+// value, itself included.
+func WithNaNEqual() Option {
+	return func(o *options) {
+		o.node.nanEqual = true
+	}
+}
+
+// floatsEqual reports whether a and b, floats of the given bit size, are
+// equal within tolerance. Infinities are only equal to themselves.
+func floatsEqual(a, b float64, bitSize int, tolerance Tolerance, nanEqual bool) bool {
+	if a == b {
+		return true
+	}
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return nanEqual && math.IsNaN(a) && math.IsNaN(b)
+	}
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return false
+	}
+
+	difference := math.Abs(a - b)
+	return difference <= tolerance.Absolute ||
+		difference <= tolerance.Relative*math.Max(math.Abs(a), math.Abs(b)) ||
+		(tolerance.ULPs > 0 && ulpDistance(a, b, bitSize) <= tolerance.ULPs)
+}
+
+// ulpDistance returns the number of floats of the given bit size between a
+// and b.
+func ulpDistance(a, b float64, bitSize int) uint64 {
+	x, y := orderedBits(a, bitSize), orderedBits(b, bitSize)
+	if x > y {
+		x, y = y, x
+	}
+	return uint64(y) - uint64(x)
+}
+
+// orderedBits maps f to an integer such that consecutive floats map to
+// consecutive integers, and -0 and +0 both map to 0.
+func orderedBits(f float64, bitSize int) int64 {
+	if bitSize == 32 {
+		bits := int64(math.Float32bits(float32(f)))
+		if bits&(1<<31) != 0 {
+			return -(bits &^ (1 << 31))
+		}
+		return bits
+	}
+	bits := int64(math.Float64bits(f))
+	if bits < 0 {
+		return -(bits & math.MaxInt64)
+	}
+	return bits
+}
@@ -0,0 +1,124 @@
+package diffanalyzer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type measurement struct {
+	Label string
+	Value float64
+	Ratio float32
+}
+
+func TestFindDifferences_FloatsWithoutTolerance_ShouldCompareExactly(t *testing.T) {
+	// Act
+	diffs := FindDifferences(measurement{Value: 3.14}, measurement{Value: 3.1400000001})
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Value", diffs[0].Path.String())
+	assert.Equal(t, Tolerance{}, diffs[0].Tolerance)
+}
+
+func TestFindDifferences_AbsoluteTolerance_ShouldIgnoreSmallDifferences(t *testing.T) {
+	// Arrange
+	expected := measurement{Value: 3.14, Ratio: 0.5}
+	actual := measurement{Value: 3.1400000001, Ratio: 0.75}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithAbsoluteTolerance(1e-6))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Ratio", diffs[0].Path.String())
+	assert.Equal(t, Tolerance{Absolute: 1e-6}, diffs[0].Tolerance)
+}
+
+func TestFindDifferences_RelativeTolerance_ShouldScaleWithMagnitude(t *testing.T) {
+	// Arrange
+	expected := []float64{1_000_000, 1}
+	actual := []float64{1_000_001, 2}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithRelativeTolerance(1e-5))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "[1]", diffs[0].Path.String())
+	assert.Equal(t, Tolerance{Relative: 1e-5}, diffs[0].Tolerance)
+}
+
+func TestFindDifferences_ULPTolerance_ShouldCountInTypePrecision(t *testing.T) {
+	// Arrange
+	next64 := math.Nextafter(1, 2)
+	next32 := math.Nextafter32(0.5, 1)
+	expected := measurement{Value: 1, Ratio: 0.5}
+	actual := measurement{Value: math.Nextafter(next64, 2), Ratio: next32}
+
+	// Act
+	oneULP := FindDifferencesWithOptions(expected, actual, WithULPTolerance(1))
+	twoULPs := FindDifferencesWithOptions(expected, actual, WithULPTolerance(2))
+
+	// Assert
+	assert.Len(t, oneULP, 1)
+	assert.Equal(t, "Value", oneULP[0].Path.String())
+	assert.Empty(t, twoULPs)
+}
+
+func TestFindDifferences_ULPTolerance_ShouldCrossZero(t *testing.T) {
+	// Arrange
+	expected := []float64{-math.SmallestNonzeroFloat64}
+	actual := []float64{math.SmallestNonzeroFloat64}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithULPTolerance(2))
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFindDifferences_ToleranceForPathAndType_ShouldOnlyApplyWhereScoped(t *testing.T) {
+	// Arrange
+	expected := measurement{Value: 1.0, Ratio: 0.5}
+	actual := measurement{Value: 1.05, Ratio: 0.55}
+
+	// Act
+	byPath := FindDifferencesWithOptions(expected, actual, ForPath("Value", WithAbsoluteTolerance(0.1)))
+	byType := FindDifferencesWithOptions(expected, actual, ForType(float32(0), WithAbsoluteTolerance(0.1)))
+
+	// Assert
+	assert.Len(t, byPath, 1)
+	assert.Equal(t, "Ratio", byPath[0].Path.String())
+	assert.Len(t, byType, 1)
+	assert.Equal(t, "Value", byType[0].Path.String())
+}
+
+func TestFindDifferences_NaN_ShouldDifferUnlessNaNEqual(t *testing.T) {
+	// Arrange
+	expected := measurement{Value: math.NaN()}
+	actual := measurement{Value: math.NaN()}
+
+	// Act
+	strict := FindDifferences(expected, actual)
+	lenient := FindDifferencesWithOptions(expected, actual, WithNaNEqual())
+
+	// Assert
+	assert.Len(t, strict, 1)
+	assert.Empty(t, lenient)
+}
+
+func TestFindDifferences_Infinities_ShouldIgnoreTolerance(t *testing.T) {
+	// Arrange
+	expected := []float64{math.Inf(1), math.Inf(1)}
+	actual := []float64{math.Inf(1), math.MaxFloat64}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithRelativeTolerance(1))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "[1]", diffs[0].Path.String())
+}
@@ -0,0 +1,268 @@
+package diffanalyzer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FormatTestOutput renders obj in a compact, human readable form, including
+// unexported struct fields.
+func FormatTestOutput(obj interface{}) string {
+	return formatValue(reflect.ValueOf(obj), make(map[visit]bool))
+}
+
+func formatValue(v reflect.Value, seen map[visit]bool) string {
+	if v.IsValid() {
+		if formatted, ok := formatTime(v); ok {
+			return formatted
+		}
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		parts := []string{}
+		typeOfT := v.Type()
+
+		for i := range v.NumField() {
+			field := typeOfT.Field(i)
+			tag := parseFieldTag(field)
+			if tag.skip {
+				continue
+			}
+			fieldValue := v.Field(i)
+
+			parts = append(parts, fmt.Sprintf("%s: %v", fieldName(field, tag), formatValue(fieldValue, seen)))
+		}
+
+		return fmt.Sprintf("{%s}", strings.Join(parts, ", "))
+
+	case reflect.String:
+		return fmt.Sprintf("\"%s\"", v.String())
+
+	case reflect.Bool:
+		return fmt.Sprintf("%t", v.Bool())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprintf("%d", v.Int())
+
+	case reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("%f", v.Float())
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "nil"
+		}
+		leave, cyclic := visiting(v, seen)
+		if cyclic {
+			return cycleMarker
+		}
+		defer leave()
+		return formatValue(v.Elem(), seen)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return formatValue(v.Elem(), seen)
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice {
+			if v.IsNil() {
+				return "nil"
+			}
+			leave, cyclic := visiting(v, seen)
+			if cyclic {
+				return cycleMarker
+			}
+			defer leave()
+		}
+
+		if v.Len() == 0 {
+			return "[]"
+		}
+
+		elements := []string{}
+
+		for i := range v.Len() {
+			elements = append(elements, formatValue(v.Index(i), seen))
+		}
+
+		return fmt.Sprintf("[%s]", strings.Join(elements, ", "))
+
+	case reflect.Map:
+		if v.IsNil() {
+			return "nil"
+		}
+		leave, cyclic := visiting(v, seen)
+		if cyclic {
+			return cycleMarker
+		}
+		defer leave()
+		var pairs []string
+		for iter := v.MapRange(); iter.Next(); {
+			pairs = append(pairs, fmt.Sprintf("%s: %s", formatValue(iter.Key(), seen), formatValue(iter.Value(), seen)))
+		}
+		sort.Strings(pairs)
+		return fmt.Sprintf("map[%s]", strings.Join(pairs, ", "))
+
+	case reflect.Invalid:
+		return "nil"
+
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// FormatComparisonValue formats objects with improved handling of types and exported fields only
+func FormatComparisonValue(obj interface{}) string {
+	return formatValueComparison(reflect.ValueOf(obj), make(map[visit]bool))
+}
+
+// formatValueComparison handles the formatting logic for different reflect.Value types
+func formatValueComparison(v reflect.Value, seen map[visit]bool) string {
+	if v.IsValid() {
+		if formatted, ok := formatTime(v); ok {
+			return formatted
+		}
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		var parts []string
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := t.Field(i)
+			tag := parseFieldTag(field)
+			if !field.IsExported() || tag.skip {
+				continue
+			}
+			fieldValue := v.Field(i)
+			parts = append(parts, fmt.Sprintf("%s: %s", fieldName(field, tag), formatValueComparison(fieldValue, seen)))
+		}
+		return fmt.Sprintf("{%s}", strings.Join(parts, ", "))
+
+	case reflect.String:
+		return fmt.Sprintf(`"%s"`, v.String())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprintf("%d", v.Int())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("%d", v.Uint())
+
+	case reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("%g", v.Float())
+
+	case reflect.Bool:
+		return fmt.Sprintf("%t", v.Bool())
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "nil"
+		}
+		leave, cyclic := visiting(v, seen)
+		if cyclic {
+			return cycleMarker
+		}
+		defer leave()
+		return formatValueComparison(v.Elem(), seen)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return formatValueComparison(v.Elem(), seen)
+
+	case reflect.Invalid:
+		return "nil"
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice {
+			if v.IsNil() {
+				return "nil"
+			}
+			leave, cyclic := visiting(v, seen)
+			if cyclic {
+				return cycleMarker
+			}
+			defer leave()
+		}
+		var elements []string
+		for i := 0; i < v.Len(); i++ {
+			elements = append(elements, formatValueComparison(v.Index(i), seen))
+		}
+		return fmt.Sprintf("[%s]", strings.Join(elements, ", "))
+
+	case reflect.Map:
+		if v.IsNil() {
+			return "nil"
+		}
+		leave, cyclic := visiting(v, seen)
+		if cyclic {
+			return cycleMarker
+		}
+		defer leave()
+		var pairs []string
+		for iter := v.MapRange(); iter.Next(); {
+			pairs = append(pairs, fmt.Sprintf("%s: %s", formatValueComparison(iter.Key(), seen), formatValueComparison(iter.Value(), seen)))
+		}
+		sort.Strings(pairs)
+		return fmt.Sprintf("map[%s]", strings.Join(pairs, ", "))
+
+	default:
+		if !v.CanInterface() {
+			// Values read from unexported fields cannot be turned back into
+			// an interface{}; fmt still prints them through reflection.
+			return fmt.Sprintf("%v", v)
+		}
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
+
+// cycleMarker replaces a value that refers back to one of its ancestors.
+const cycleMarker = "<cycle>"
+
+// visiting marks the pointer, map or slice v as being formatted. It reports
+// cyclic when v is already being formatted further up, otherwise the caller
+// must call leave once done with v.
+func visiting(v reflect.Value, seen map[visit]bool) (leave func(), cyclic bool) {
+	key := newVisit(v)
+	if seen[key] {
+		return nil, true
+	}
+	seen[key] = true
+	return func() { delete(seen, key) }, false
+}
+
+// FormatDiffValue formats a FieldDiff Expected or Actual value for display,
+// quoting strings and delegating composite values to FormatComparisonValue.
+func FormatDiffValue(value interface{}) string {
+	if value == nil {
+		return "<nil>"
+	}
+
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case int, int8, int16, int32, int64:
+		return fmt.Sprintf("%v", v)
+	case uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%v", v)
+	case float32, float64:
+		return fmt.Sprintf("%v", v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.String()
+	case CycleRef:
+		return v.String()
+	case ByteWindow:
+		return v.String()
+	default:
+		// Para tipos complexos, usa nosso formatter de comparação
+		return FormatComparisonValue(v)
+	}
+}
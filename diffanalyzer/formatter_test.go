@@ -1,4 +1,4 @@
-package main
+package diffanalyzer
 
 import (
 	"testing"
@@ -7,7 +7,7 @@ import (
 func TestFormatComparisonValue_String(t *testing.T) {
 	input := "hello world"
 	expected := `"hello world"`
-	result := formatComparisonValue(input)
+	result := FormatComparisonValue(input)
 
 	if result != expected {
 		t.Errorf("Expected %s, got %s", expected, result)
@@ -17,7 +17,7 @@ func TestFormatComparisonValue_String(t *testing.T) {
 func TestFormatComparisonValue_Int(t *testing.T) {
 	input := 42
 	expected := "42"
-	result := formatComparisonValue(input)
+	result := FormatComparisonValue(input)
 
 	if result != expected {
 		t.Errorf("Expected %s, got %s", expected, result)
@@ -27,7 +27,7 @@ func TestFormatComparisonValue_Int(t *testing.T) {
 func TestFormatComparisonValue_Uint(t *testing.T) {
 	input := uint(42)
 	expected := "42"
-	result := formatComparisonValue(input)
+	result := FormatComparisonValue(input)
 
 	if result != expected {
 		t.Errorf("Expected %s, got %s", expected, result)
@@ -37,7 +37,7 @@ func TestFormatComparisonValue_Uint(t *testing.T) {
 func TestFormatComparisonValue_Float(t *testing.T) {
 	input := 3.14159
 	expected := "3.14159"
-	result := formatComparisonValue(input)
+	result := FormatComparisonValue(input)
 
 	if result != expected {
 		t.Errorf("Expected %s, got %s", expected, result)
@@ -54,7 +54,7 @@ func TestFormatComparisonValue_Bool(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result := formatComparisonValue(test.input)
+		result := FormatComparisonValue(test.input)
 		if result != test.expected {
 			t.Errorf("Expected %s, got %s", test.expected, result)
 		}
@@ -75,7 +75,7 @@ func TestFormatComparisonValue_Slice(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			result := formatComparisonValue(test.input)
+			result := FormatComparisonValue(test.input)
 			if result != test.expected {
 				t.Errorf("Expected %s, got %s", test.expected, result)
 			}
@@ -95,7 +95,7 @@ func TestFormatComparisonValue_Map(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			result := formatComparisonValue(test.input)
+			result := FormatComparisonValue(test.input)
 			// Note: map iteration order is not guaranteed, so we might need to adjust this test
 			if result != test.expected {
 				t.Errorf("Expected %s, got %s", test.expected, result)
@@ -120,7 +120,7 @@ func TestFormatComparisonValue_Pointer(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			result := formatComparisonValue(test.input)
+			result := FormatComparisonValue(test.input)
 			if result != test.expected {
 				t.Errorf("Expected %s, got %s", test.expected, result)
 			}
@@ -159,7 +159,7 @@ func TestFormatComparisonValue_Struct(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			result := formatComparisonValue(test.input)
+			result := FormatComparisonValue(test.input)
 			if result != test.expected {
 				t.Errorf("Expected %s, got %s", test.expected, result)
 			}
@@ -190,7 +190,7 @@ func TestFormatComparisonValue_ComplexTypes(t *testing.T) {
 		Hobbies: []string{"reading", "gaming"},
 	}
 
-	result := formatComparisonValue(person)
+	result := FormatComparisonValue(person)
 	expected := `{Name: "John", Age: 30, Address: {Street: "123 Main St", City: "New York"}, Hobbies: ["reading", "gaming"]}`
 
 	if result != expected {
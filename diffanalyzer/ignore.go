@@ -0,0 +1,31 @@
+package diffanalyzer
+
+// WithIgnoredPaths skips every value whose path matches one of patterns, such
+// as "UpdatedAt", "Profile.Address.City", "*.ID" or "PersonMap.[*].Profile.Bio".
+// Ignored values are not traversed at all. See pathPattern for the wildcard
+// syntax.
+func WithIgnoredPaths(patterns ...string) Option {
+	return func(o *options) {
+		for _, p := range patterns {
+			o.ignored = append(o.ignored, compilePattern(p))
+		}
+	}
+}
+
+func (c *comparer) ignored(path Path) bool {
+	for _, p := range c.opts.ignored {
+		if p.match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// report records diff unless its path is ignored. Added and removed elements
+// are reported without being traversed, so they are filtered here as well.
+func (c *comparer) report(diff FieldDiff) {
+	if c.ignored(diff.Path) {
+		return
+	}
+	c.diffs = append(c.diffs, diff)
+}
@@ -0,0 +1,113 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/seu-usuario/meu-projeto/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDifferencesWithOptions_IgnoredExactPath_ShouldSkipField(t *testing.T) {
+	// Arrange
+	person1 := models.Person{Name: "Alice", Profile: models.Profile{Address: models.Address{City: "São Paulo", Country: "Brasil"}}}
+	person2 := models.Person{Name: "Bob", Profile: models.Profile{Address: models.Address{City: "Rio", Country: "Brazil"}}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(person1, person2, WithIgnoredPaths("Name", "Profile.Address.City"))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Profile.Address.Country", diffs[0].Path.String())
+}
+
+func TestFindDifferencesWithOptions_IgnoredSubtree_ShouldSkipNestedFields(t *testing.T) {
+	// Arrange
+	person1 := models.Person{ID: 1, Profile: models.Profile{Bio: "Engineer", Tags: []string{"go"}}}
+	person2 := models.Person{ID: 1, Profile: models.Profile{Bio: "Developer", Tags: []string{"rust"}}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(person1, person2, WithIgnoredPaths("Profile"))
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFindDifferencesWithOptions_IgnoredWildcards_ShouldMatchAnySegment(t *testing.T) {
+	// Arrange
+	expected := models.ItemCollection{Items: []models.Item{
+		{ID: 1, Status: "active", Value: 100},
+		{ID: 3, Status: "active", Value: 150},
+	}}
+	actual := models.ItemCollection{Items: []models.Item{
+		{ID: 1, Status: "active", Value: 110},
+		{ID: 3, Status: "inactive", Value: 140},
+	}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithIgnoredPaths("Items.[*].Value"))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Items.[1].Status", diffs[0].Path.String())
+}
+
+func TestFindDifferencesWithOptions_IgnoredMapWildcard_ShouldSkipNestedFieldInEveryEntry(t *testing.T) {
+	// Arrange
+	container1 := models.MapContainer{PersonMap: map[string]models.Person{
+		"employee1": {Name: "John", Profile: models.Profile{Bio: "Senior Developer"}},
+		"employee2": {Name: "Jane", Profile: models.Profile{Bio: "Manager"}},
+	}}
+	container2 := models.MapContainer{PersonMap: map[string]models.Person{
+		"employee1": {Name: "John", Profile: models.Profile{Bio: "Lead Developer"}},
+		"employee2": {Name: "Janet", Profile: models.Profile{Bio: "Director"}},
+	}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(container1, container2, WithIgnoredPaths("PersonMap.[*].Profile.Bio"))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "PersonMap.[employee2].Name", diffs[0].Path.String())
+}
+
+func TestFindDifferencesWithOptions_IgnoredAddedElement_ShouldNotBeReported(t *testing.T) {
+	// Arrange
+	container1 := models.MapContainer{StringMap: map[string]string{"key1": "value1"}}
+	container2 := models.MapContainer{StringMap: map[string]string{"key1": "value1", "updated_at": "now"}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(container1, container2, WithIgnoredPaths("StringMap.[updated_at]"))
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestPathPattern_Match(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"Name", "Name", true},
+		{"Name", "Profile.Name", false},
+		{"*.ID", "Profile.ID", true},
+		{"*.ID", "ID", false},
+		{"**.ID", "ID", true},
+		{"**.ID", "Items.[3].ID", true},
+		{"Items.[*].Value", "Items.[3].Value", true},
+		{"Items.[*].Value", "Items.[ID=3].Value", true},
+		{"Items.[*].Value", "Items.Value", false},
+		{"StringMap.[key*]", "StringMap.[key1]", true},
+		{"StringMap.[a.b]", "StringMap.[a.b]", true},
+		{"StringMap.[a.*]", "StringMap.[a.b]", true},
+		{"StringMap.*", "StringMap.[a.b]", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern+" "+test.path, func(t *testing.T) {
+			path, err := ParsePath(test.path)
+			assert.NoError(t, err)
+			assert.Equal(t, test.match, compilePattern(test.pattern).match(path))
+		})
+	}
+}
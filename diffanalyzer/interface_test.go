@@ -0,0 +1,105 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/seu-usuario/meu-projeto/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type envelope struct {
+	Kind    string
+	Payload interface{}
+}
+
+func TestFindDifferences_NilVsNonNilInterface_ShouldReportAddedAndRemoved(t *testing.T) {
+	// Arrange
+	empty := envelope{Kind: "event"}
+	filled := envelope{Kind: "event", Payload: "hello"}
+
+	// Act
+	added := FindDifferences(empty, filled)
+	removed := FindDifferences(filled, empty)
+
+	// Assert
+	assert.Len(t, added, 1)
+	assert.Equal(t, "Payload", added[0].Path.String())
+	assert.Equal(t, Added, added[0].Change)
+	assert.Nil(t, added[0].Expected)
+	assert.Equal(t, "hello", added[0].Actual)
+
+	assert.Len(t, removed, 1)
+	assert.Equal(t, Removed, removed[0].Change)
+}
+
+func TestFindDifferences_BothNilInterfaces_ShouldReturnNoDifferences(t *testing.T) {
+	// Act
+	diffs := FindDifferences(envelope{}, envelope{})
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFindDifferences_DifferentDynamicTypes_ShouldReportTypeNames(t *testing.T) {
+	// Arrange
+	person := envelope{Payload: models.Person{Name: "Alice"}}
+	pessoa := envelope{Payload: models.Pessoa{Nome: "Alice"}}
+
+	// Act
+	diffs := FindDifferences(person, pessoa)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Payload", diffs[0].Path.String())
+	assert.Equal(t, TypeChanged, diffs[0].Change)
+	assert.Equal(t, "models.Person", diffs[0].Expected)
+	assert.Equal(t, "models.Pessoa", diffs[0].Actual)
+}
+
+func TestFindDifferences_SameDynamicType_ShouldRecurseIntoValue(t *testing.T) {
+	// Arrange
+	expected := envelope{Payload: models.Person{Name: "Alice"}}
+	actual := envelope{Payload: models.Person{Name: "Bob"}}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Payload.Name", diffs[0].Path.String())
+}
+
+func TestFindDifferences_InterfaceSliceElements_ShouldCompareDynamicValues(t *testing.T) {
+	// Arrange
+	expected := []interface{}{1, "two", nil}
+	actual := []interface{}{1, 2, "three"}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "[1]", diffs[0].Path.String())
+	assert.Equal(t, TypeChanged, diffs[0].Change)
+	assert.Equal(t, "string", diffs[0].Expected)
+	assert.Equal(t, "int", diffs[0].Actual)
+	assert.Equal(t, "[2]", diffs[1].Path.String())
+	assert.Equal(t, Added, diffs[1].Change)
+}
+
+func TestFindDifferences_UntypedNilRoot_ShouldNotPanic(t *testing.T) {
+	// Act
+	bothNil := FindDifferences(nil, nil)
+	oneNil := FindDifferences(nil, models.Address{})
+
+	// Assert
+	assert.Empty(t, bothNil)
+	assert.Len(t, oneNil, 1)
+	assert.Equal(t, Added, oneNil[0].Change)
+}
+
+func TestFormatComparisonValue_Interfaces_ShouldFormatDynamicValue(t *testing.T) {
+	assert.Equal(t, `{Kind: "event", Payload: nil}`, FormatComparisonValue(envelope{Kind: "event"}))
+	assert.Equal(t, `{Kind: "event", Payload: "hello"}`, FormatComparisonValue(envelope{Kind: "event", Payload: "hello"}))
+	assert.Equal(t, "nil", FormatComparisonValue(nil))
+}
@@ -0,0 +1,141 @@
+package diffanalyzer
+
+import "reflect"
+
+// WithSliceKey declares field as the identity of the struct type of sample.
+// Slices whose elements are of that type (or pointers to it) are matched by
+// the value of field instead of by position, so reordering them yields no
+// difference and changes are reported as e.g. "Items.[ID=3].Value".
+//
+// A field can also be declared as the identity of its struct with the tag
+// `diff:",key"`.
+func WithSliceKey(sample interface{}, field string) Option {
+	t := reflect.TypeOf(sample)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return func(o *options) {
+		if o.sliceKeys == nil {
+			o.sliceKeys = make(map[reflect.Type]string)
+		}
+		o.sliceKeys[t] = field
+	}
+}
+
+// sliceKey returns the identity field declared for elements of type elem,
+// either through WithSliceKey or a `diff:",key"` tag.
+func (c *comparer) sliceKey(elem reflect.Type) (reflect.StructField, bool) {
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+
+	if name, ok := c.opts.sliceKeys[elem]; ok {
+		field, found := elem.FieldByName(name)
+		return field, found && field.IsExported() && field.Type.Comparable()
+	}
+
+	for i := range elem.NumField() {
+		field := elem.Field(i)
+		if parseFieldTag(field).key && field.IsExported() && field.Type.Comparable() {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// compareKeyed matches the elements of two slices by their key field. Elements
+// found on both sides are compared recursively, the rest are reported as
+// removed or added. It falls back to compareSequence when an element has no
+// usable key (a nil pointer, an unreachable, uncomparable or duplicated key).
+func (c *comparer) compareKeyed(expected, actual reflect.Value, path Path, key reflect.StructField, tag fieldTag) {
+	expectedKeys, ok := elementKeys(expected, key)
+	if !ok {
+		c.compareSequence(expected, actual, path, tag)
+		return
+	}
+	actualKeys, ok := elementKeys(actual, key)
+	if !ok {
+		c.compareSequence(expected, actual, path, tag)
+		return
+	}
+
+	actualIndex := make(map[interface{}]int, len(actualKeys))
+	for j, k := range actualKeys {
+		actualIndex[k] = j
+	}
+
+	name := c.keyName(key)
+	for i, k := range expectedKeys {
+		keyPath := path.Keyed(name, k)
+		j, found := actualIndex[k]
+		if !found {
+			c.report(FieldDiff{
+				Path:     keyPath,
+				Expected: reportedValue(expected.Index(i)),
+				Actual:   nil,
+				Change:   Removed,
+			})
+			continue
+		}
+		c.compare(expected.Index(i), actual.Index(j), keyPath, tag)
+	}
+
+	expectedIndex := make(map[interface{}]bool, len(expectedKeys))
+	for _, k := range expectedKeys {
+		expectedIndex[k] = true
+	}
+	for j, k := range actualKeys {
+		if expectedIndex[k] {
+			continue
+		}
+		c.report(FieldDiff{
+			Path:     path.Keyed(name, k),
+			Expected: nil,
+			Actual:   reportedValue(actual.Index(j)),
+			Change:   Added,
+		})
+	}
+}
+
+// keyName returns the name of the key field in keyed path steps, following
+// the field naming like the other path segments.
+func (c *comparer) keyName(key reflect.StructField) string {
+	encoded := c.opts.naming.encode(key, parseFieldTag(key))
+	if encoded.skip {
+		return key.Name
+	}
+	return encoded.name
+}
+
+// elementKeys returns the key of every element of s, or false if an element
+// is a nil pointer, has a key that cannot be reached (promoted through a nil
+// embedded pointer), read (reached through an unexported field) or compared
+// (an interface holding a slice, map or func) or two elements share the same
+// key.
+func elementKeys(s reflect.Value, key reflect.StructField) ([]interface{}, bool) {
+	keys := make([]interface{}, s.Len())
+	seen := make(map[interface{}]bool, s.Len())
+	for i := range s.Len() {
+		elem := s.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				return nil, false
+			}
+			elem = elem.Elem()
+		}
+		field, err := elem.FieldByIndexErr(key.Index)
+		if err != nil || !field.CanInterface() || !field.Comparable() {
+			return nil, false
+		}
+		k := field.Interface()
+		if seen[k] {
+			return nil, false
+		}
+		seen[k] = true
+		keys[i] = k
+	}
+	return keys, true
+}
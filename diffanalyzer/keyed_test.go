@@ -0,0 +1,124 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/seu-usuario/meu-projeto/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDifferencesWithOptions_SliceKeyReordered_ShouldReturnNoDifferences(t *testing.T) {
+	// Arrange
+	expected := models.ItemCollection{Items: []models.Item{
+		{ID: 1, Status: "active", Value: 100},
+		{ID: 3, Status: "active", Value: 150},
+	}}
+	actual := models.ItemCollection{Items: []models.Item{
+		{ID: 3, Status: "active", Value: 150},
+		{ID: 1, Status: "active", Value: 100},
+	}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithSliceKey(models.Item{}, "ID"))
+
+	// Assert
+	assert.Empty(t, diffs, "Reordered elements with the same keys should be equal")
+}
+
+func TestFindDifferencesWithOptions_SliceKey_ShouldReportKeyedPaths(t *testing.T) {
+	// Arrange
+	expected := models.ItemCollection{Items: []models.Item{
+		{ID: 1, Status: "active", Value: 100},
+		{ID: 3, Status: "active", Value: 150},
+		{ID: 5, Status: "active", Value: 300},
+	}}
+	actual := models.ItemCollection{Items: []models.Item{
+		{ID: 7, Status: "new", Value: 10},
+		{ID: 3, Status: "active", Value: 140},
+		{ID: 1, Status: "active", Value: 100},
+	}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithSliceKey(models.Item{}, "ID"))
+
+	// Assert
+	assert.Len(t, diffs, 3)
+	assert.Equal(t, "Items.[ID=3].Value", diffs[0].Path.String())
+	assert.Equal(t, Modified, diffs[0].Change)
+	assert.Equal(t, 150, diffs[0].Expected)
+	assert.Equal(t, 140, diffs[0].Actual)
+	assert.Equal(t, "Items.[ID=5]", diffs[1].Path.String())
+	assert.Equal(t, Removed, diffs[1].Change)
+	assert.Equal(t, "Items.[ID=7]", diffs[2].Path.String())
+	assert.Equal(t, Added, diffs[2].Change)
+}
+
+func TestFindDifferences_KeyTag_ShouldMatchElementsByKey(t *testing.T) {
+	// Arrange
+	type Order struct {
+		Number string `diff:",key"`
+		Total  int
+	}
+
+	expected := []*Order{{Number: "A-1", Total: 10}, {Number: "B-2", Total: 20}}
+	actual := []*Order{{Number: "B-2", Total: 25}, {Number: "A-1", Total: 10}}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "[Number=B-2].Total", diffs[0].Path.String())
+}
+
+func TestFindDifferencesWithOptions_DuplicatedKeys_ShouldFallBackToPositions(t *testing.T) {
+	// Arrange
+	expected := []models.Item{{ID: 1, Value: 100}, {ID: 1, Value: 200}}
+	actual := []models.Item{{ID: 1, Value: 100}, {ID: 1, Value: 250}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithSliceKey(models.Item{}, "ID"))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "[1].Value", diffs[0].Path.String())
+}
+
+func TestFindDifferences_UnhashableInterfaceKey_ShouldFallBackToPositions(t *testing.T) {
+	// Arrange
+	type Entry struct {
+		ID    interface{} `diff:",key"`
+		Value int
+	}
+	expected := []Entry{{ID: []int{1}, Value: 1}, {ID: 2, Value: 2}}
+	actual := []Entry{{ID: []int{1}, Value: 1}, {ID: 2, Value: 3}}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "[1].Value", diffs[0].Path.String())
+}
+
+type keyedInner struct {
+	ID int
+}
+
+type keyedOuter struct {
+	*keyedInner
+	X int
+}
+
+func TestFindDifferencesWithOptions_KeyBehindNilEmbeddedPointer_ShouldFallBackToPositions(t *testing.T) {
+	// Arrange
+	expected := []keyedOuter{{keyedInner: &keyedInner{ID: 1}, X: 1}, {X: 2}}
+	actual := []keyedOuter{{keyedInner: &keyedInner{ID: 1}, X: 1}, {X: 3}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithSliceKey(keyedOuter{}, "ID"))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "[1].X", diffs[0].Path.String())
+}
@@ -0,0 +1,91 @@
+package diffanalyzer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithStrictKinds refuses the kinds that can only be compared by identity:
+// channels, funcs and unsafe pointers. Values of those kinds are left
+// uncompared and Differ.Diff returns an *UnsupportedKindError listing them,
+// instead of a difference that may only mean two distinct but equivalent
+// values were found.
+func WithStrictKinds() Option {
+	return func(o *options) {
+		o.strictKinds = true
+	}
+}
+
+// UnsupportedKindError lists the values a comparison could not compare.
+type UnsupportedKindError struct {
+	// Kinds holds each refused kind once, in the order they were met.
+	Kinds []reflect.Kind
+	// Paths holds the path of every refused value.
+	Paths []Path
+}
+
+func (e *UnsupportedKindError) Error() string {
+	kinds := make([]string, len(e.Kinds))
+	for i, kind := range e.Kinds {
+		kinds[i] = kind.String()
+	}
+	paths := make([]string, len(e.Paths))
+	for i, path := range e.Paths {
+		paths[i] = path.String()
+		if paths[i] == "" {
+			paths[i] = "<root>"
+		}
+	}
+	return fmt.Sprintf("diffanalyzer: cannot compare values of kind %s at %s",
+		strings.Join(kinds, ", "), strings.Join(paths, ", "))
+}
+
+// refuse records that the value of the given kind at path was not compared.
+func (c *comparer) refuse(kind reflect.Kind, path Path) {
+	c.unsupported.addKind(kind)
+	c.unsupported.Paths = append(c.unsupported.Paths, path)
+}
+
+// addKind records kind unless it is already listed.
+func (e *UnsupportedKindError) addKind(kind reflect.Kind) {
+	for _, k := range e.Kinds {
+		if k == kind {
+			return
+		}
+	}
+	e.Kinds = append(e.Kinds, kind)
+}
+
+// merge records the values refused in other as refused in e too.
+func (e *UnsupportedKindError) merge(other *UnsupportedKindError) {
+	for _, kind := range other.Kinds {
+		e.addKind(kind)
+	}
+	e.Paths = append(e.Paths, other.Paths...)
+}
+
+// compareIdentity compares two channels, funcs or unsafe pointers by the
+// address they hold: channels are equal when they are the same channel and
+// funcs when they are both nil or share the same code, as two closures over
+// different variables cannot be told apart.
+func (c *comparer) compareIdentity(expected, actual reflect.Value, path Path) {
+	if c.opts.strictKinds {
+		c.refuse(expected.Kind(), path)
+		return
+	}
+	if expected.Pointer() == actual.Pointer() {
+		return
+	}
+
+	change := Modified
+	if expected.IsNil() != actual.IsNil() {
+		change = presenceChange(actual.IsNil())
+	}
+	c.report(FieldDiff{
+		Path:     path,
+		Expected: reportedValue(expected),
+		Actual:   reportedValue(actual),
+		Change:   change,
+	})
+}
@@ -0,0 +1,130 @@
+package diffanalyzer
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type signal struct {
+	Phase   complex128
+	Gain    complex64
+	Address uintptr
+	Events  chan string
+	Handler func() string
+	Raw     unsafe.Pointer
+}
+
+func TestFindDifferences_ComplexAndUintptr_ShouldCompareValues(t *testing.T) {
+	// Arrange
+	expected := signal{Phase: complex(1, 2), Gain: complex(3, 4), Address: 0x10}
+	actual := signal{Phase: complex(1, 2.5), Gain: complex(3, 4), Address: 0x20}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "Phase", diffs[0].Path.String())
+	assert.Equal(t, complex(1, 2), diffs[0].Expected)
+	assert.Equal(t, complex(1, 2.5), diffs[0].Actual)
+	assert.Equal(t, "Address", diffs[1].Path.String())
+	assert.Equal(t, uintptr(0x20), diffs[1].Actual)
+}
+
+func TestFindDifferences_ChannelsAndUnsafePointers_ShouldCompareByIdentity(t *testing.T) {
+	// Arrange
+	events := make(chan string)
+	value, other := 1, 1
+	expected := signal{Events: events, Raw: unsafe.Pointer(&value)}
+	same := signal{Events: events, Raw: unsafe.Pointer(&value)}
+	different := signal{Events: make(chan string), Raw: unsafe.Pointer(&other)}
+
+	// Act
+	sameDiffs := FindDifferences(expected, same)
+	differentDiffs := FindDifferences(expected, different)
+
+	// Assert
+	assert.Empty(t, sameDiffs)
+	assert.Len(t, differentDiffs, 2)
+	assert.Equal(t, "Events", differentDiffs[0].Path.String())
+	assert.Equal(t, Modified, differentDiffs[0].Change)
+	assert.Equal(t, "Raw", differentDiffs[1].Path.String())
+}
+
+func TestFindDifferences_NilVsNonNilFunc_ShouldReportAddedAndRemoved(t *testing.T) {
+	// Arrange
+	handler := func() string { return "ok" }
+
+	// Act
+	added := FindDifferences(signal{}, signal{Handler: handler})
+	removed := FindDifferences(signal{Handler: handler}, signal{})
+	same := FindDifferences(signal{Handler: handler}, signal{Handler: handler})
+
+	// Assert
+	assert.Len(t, added, 1)
+	assert.Equal(t, "Handler", added[0].Path.String())
+	assert.Equal(t, Added, added[0].Change)
+	assert.Len(t, removed, 1)
+	assert.Equal(t, Removed, removed[0].Change)
+	assert.Empty(t, same)
+}
+
+func TestDiffer_Diff_StrictKinds_ShouldReturnUnsupportedKinds(t *testing.T) {
+	// Arrange
+	differ := NewDiffer(WithStrictKinds())
+	expected := signal{Phase: 1, Events: make(chan string)}
+	actual := signal{Phase: 2, Handler: func() string { return "ok" }}
+
+	// Act
+	diffs, err := differ.Diff(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Phase", diffs[0].Path.String())
+
+	var unsupported *UnsupportedKindError
+	if assert.ErrorAs(t, err, &unsupported) {
+		assert.Equal(t, []reflect.Kind{reflect.Chan, reflect.Func, reflect.UnsafePointer}, unsupported.Kinds)
+		assert.Len(t, unsupported.Paths, 3)
+	}
+	assert.EqualError(t, err, "diffanalyzer: cannot compare values of kind chan, func, unsafe.Pointer at Events, Handler, Raw")
+}
+
+func TestDiffer_Diff_WithoutUnsupportedKinds_ShouldReturnNilError(t *testing.T) {
+	// Arrange
+	expected := map[string]complex128{"phase": complex(1, 0)}
+	actual := map[string]complex128{"phase": complex(2, 0)}
+
+	// Act
+	diffs, err := NewDiffer(WithStrictKinds()).Diff(expected, actual)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, diffs, 1)
+}
+
+func TestDiffer_Diff_StrictKindsWithUnorderedSlices_ShouldNotReportTrialPairings(t *testing.T) {
+	// Arrange
+	type task struct {
+		Run      func() string
+		Priority int
+	}
+	run := func() string { return "ok" }
+	differ := NewDiffer(WithStrictKinds(), WithUnorderedSlices())
+	expected := []task{{Run: run, Priority: 1}, {Run: run, Priority: 2}}
+	actual := []task{{Run: run, Priority: 3}, {Run: run, Priority: 1}}
+
+	// Act
+	_, err := differ.Diff(expected, actual)
+
+	// Assert
+	var unsupported *UnsupportedKindError
+	if assert.ErrorAs(t, err, &unsupported) {
+		assert.Equal(t, []reflect.Kind{reflect.Func}, unsupported.Kinds)
+		assert.EqualError(t, err, "diffanalyzer: cannot compare values of kind func at [0].Run",
+			"Only the pairing kept should be reported")
+	}
+}
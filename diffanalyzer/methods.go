@@ -0,0 +1,109 @@
+package diffanalyzer
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// WithEqualMethods compares values whose type has an Equal method, such as
+// (T) Equal(T) bool, by calling it instead of traversing their fields. When
+// Equal reports false a single Modified difference holding both values is
+// reported at their path.
+func WithEqualMethods() Option {
+	return func(o *options) {
+		o.node.equalMethods = true
+	}
+}
+
+// WithTextComparison compares values implementing encoding.TextMarshaler by
+// the text they marshal to, and other values implementing fmt.Stringer by
+// their String output. Differences are reported at the value's path with
+// the texts as Expected and Actual. Values whose MarshalText fails are
+// compared field by field.
+func WithTextComparison() Option {
+	return func(o *options) {
+		o.node.textComparison = true
+	}
+}
+
+// compareMethods compares expected and actual through their Equal, MarshalText
+// or String methods when the options of their node allow it, and reports
+// whether it did.
+func (c *comparer) compareMethods(expected, actual reflect.Value, path Path, tag fieldTag) bool {
+	t := expected.Type()
+	if t != actual.Type() || t.Kind() == reflect.Interface || !expected.CanInterface() || !actual.CanInterface() {
+		return false
+	}
+	if t.Kind() == reflect.Ptr && (expected.IsNil() || actual.IsNil()) {
+		return false
+	}
+
+	node := c.nodeOptions(path, t, tag)
+	if !node.equalMethods && !node.textComparison {
+		return false
+	}
+
+	if equal, ok := equalMethod(t); ok && node.equalMethods {
+		if !equal.Func.Call([]reflect.Value{expected, actual})[0].Bool() {
+			c.report(FieldDiff{
+				Path:     path,
+				Expected: expected.Interface(),
+				Actual:   actual.Interface(),
+				Change:   Modified,
+			})
+		}
+		return true
+	}
+
+	if !node.textComparison {
+		return false
+	}
+	expectedText, ok := textOf(expected)
+	if !ok {
+		return false
+	}
+	actualText, ok := textOf(actual)
+	if !ok {
+		return false
+	}
+	if expectedText != actualText {
+		c.report(FieldDiff{
+			Path:     path,
+			Expected: expectedText,
+			Actual:   actualText,
+			Change:   Modified,
+		})
+	}
+	return true
+}
+
+// equalMethod returns the Equal method of t when it takes a value of type t
+// and returns a bool.
+func equalMethod(t reflect.Type) (reflect.Method, bool) {
+	method, found := t.MethodByName("Equal")
+	if !found {
+		return reflect.Method{}, false
+	}
+	signature := method.Type
+	valid := signature.NumIn() == 2 && t.AssignableTo(signature.In(1)) &&
+		signature.NumOut() == 1 && signature.Out(0).Kind() == reflect.Bool
+	return method, valid
+}
+
+// textOf returns the text v marshals to, or its String output.
+func textOf(v reflect.Value) (string, bool) {
+	switch {
+	case v.Type().Implements(textMarshalerType):
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err == nil
+	case v.Type().Implements(stringerType):
+		return v.Interface().(fmt.Stringer).String(), true
+	}
+	return "", false
+}
@@ -0,0 +1,113 @@
+package diffanalyzer
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// version ignores its build metadata when compared with Equal.
+type version struct {
+	Major, Minor int
+	Build        string
+}
+
+func (v version) Equal(other version) bool {
+	return v.Major == other.Major && v.Minor == other.Minor
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+}
+
+// sku is an identifier whose case is not significant.
+type sku struct {
+	Code string
+}
+
+func (s sku) String() string {
+	return strings.ToUpper(s.Code)
+}
+
+// brokenText cannot be marshaled.
+type brokenText struct {
+	Value string
+}
+
+func (brokenText) MarshalText() ([]byte, error) {
+	return nil, errors.New("not marshalable")
+}
+
+type release struct {
+	Version version
+	Product sku
+	Mirror  net.IP
+	Notes   brokenText
+}
+
+func TestFindDifferences_WithoutEqualMethods_ShouldCompareFields(t *testing.T) {
+	// Act
+	diffs := FindDifferences(release{Version: version{1, 2, "a"}}, release{Version: version{1, 2, "b"}})
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Version.Build", diffs[0].Path.String())
+}
+
+func TestFindDifferences_WithEqualMethods_ShouldReportAtTypeBoundary(t *testing.T) {
+	// Arrange
+	expected := []release{{Version: version{1, 2, "a"}}, {Version: version{1, 2, "a"}}}
+	actual := []release{{Version: version{1, 2, "b"}}, {Version: version{1, 3, "a"}}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithEqualMethods())
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "[1].Version", diffs[0].Path.String())
+	assert.Equal(t, Modified, diffs[0].Change)
+	assert.Equal(t, version{1, 2, "a"}, diffs[0].Expected)
+	assert.Equal(t, version{1, 3, "a"}, diffs[0].Actual)
+}
+
+func TestFindDifferences_WithTextComparison_ShouldCompareStringOutput(t *testing.T) {
+	// Arrange
+	expected := release{Product: sku{"ab-1"}, Mirror: net.ParseIP("10.0.0.1")}
+	actual := release{Product: sku{"AB-1"}, Mirror: net.ParseIP("10.0.0.2").To4()}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithTextComparison())
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Mirror", diffs[0].Path.String())
+	assert.Equal(t, "10.0.0.1", diffs[0].Expected)
+	assert.Equal(t, "10.0.0.2", diffs[0].Actual)
+}
+
+func TestFindDifferences_WithTextComparison_ShouldPreferEqualMethods(t *testing.T) {
+	// Arrange
+	expected := release{Version: version{1, 2, "a"}}
+	actual := release{Version: version{1, 2, "b"}}
+
+	// Act
+	both := FindDifferencesWithOptions(expected, actual, WithEqualMethods(), WithTextComparison())
+	scoped := FindDifferencesWithOptions(expected, actual, ForType(version{}, WithEqualMethods()))
+
+	// Assert
+	assert.Empty(t, both)
+	assert.Empty(t, scoped)
+}
+
+func TestFindDifferences_WithTextComparison_ShouldFallBackWhenMarshalFails(t *testing.T) {
+	// Act
+	diffs := FindDifferencesWithOptions(release{Notes: brokenText{"a"}}, release{Notes: brokenText{"b"}}, WithTextComparison())
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Notes.Value", diffs[0].Path.String())
+}
@@ -0,0 +1,100 @@
+package diffanalyzer
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldNaming selects how struct fields are named in reported paths.
+type FieldNaming int
+
+const (
+	// GoNames uses the Go field names, e.g. "Profile.Address.City".
+	GoNames FieldNaming = iota
+	// JSONNames uses the name from the `json` tag when present, e.g.
+	// "profile.address.city", falling back to the Go field name.
+	JSONNames
+	// YAMLNames uses the name from the `yaml` tag when present, falling back
+	// to the Go field name.
+	YAMLNames
+)
+
+// WithFieldNaming names struct fields in paths according to naming. Under
+// JSONNames and YAMLNames, fields tagged "-" are skipped, fields tagged
+// omitempty are skipped when empty on both sides, and untagged embedded
+// structs are flattened into their parent, as the encoders do. A name given
+// in the `diff` tag still takes precedence.
+func WithFieldNaming(naming FieldNaming) Option {
+	return func(o *options) {
+		o.naming = naming
+	}
+}
+
+// encodedField describes how a struct field is encoded under a FieldNaming.
+type encodedField struct {
+	name      string
+	skip      bool
+	omitEmpty bool
+	inline    bool
+}
+
+func (n FieldNaming) encode(field reflect.StructField, tag fieldTag) encodedField {
+	if tag.skip {
+		return encodedField{skip: true}
+	}
+
+	var key string
+	switch n {
+	case JSONNames:
+		key = "json"
+	case YAMLNames:
+		key = "yaml"
+	default:
+		return encodedField{name: fieldName(field, tag)}
+	}
+
+	encoding, ok := field.Tag.Lookup(key)
+	if encoding == "-" {
+		return encodedField{skip: true}
+	}
+
+	name, flags, _ := strings.Cut(encoding, ",")
+	encoded := encodedField{name: name}
+	for _, flag := range strings.Split(flags, ",") {
+		if flag == "omitempty" {
+			encoded.omitEmpty = true
+		}
+	}
+	if tag.name != "" {
+		encoded.name = tag.name
+	}
+	if encoded.name == "" {
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		encoded.inline = field.Anonymous && !ok && fieldType.Kind() == reflect.Struct
+		encoded.name = field.Name
+	}
+	return encoded
+}
+
+// isEmptyValue reports whether v is empty in the sense of the omitempty
+// option of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type payloadAddress struct {
+	City    string `json:"city" yaml:"cidade"`
+	Country string `json:"country,omitempty"`
+}
+
+type payloadProfile struct {
+	Bio     string         `json:"bio"`
+	Address payloadAddress `json:"address"`
+}
+
+type PayloadAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type payloadPerson struct {
+	PayloadAudit
+	ID       int            `json:"id"`
+	Name     string         `json:"name" diff:"full_name"`
+	Password string         `json:"-"`
+	Nickname string         `json:",omitempty"`
+	Emails   []string       `json:"emails,omitempty"`
+	Profile  payloadProfile `json:"profile"`
+}
+
+func TestFindDifferencesWithOptions_JSONNames_ShouldUseJSONTagsInPaths(t *testing.T) {
+	// Arrange
+	person1 := payloadPerson{ID: 1, Profile: payloadProfile{Address: payloadAddress{City: "São Paulo"}}}
+	person2 := payloadPerson{ID: 2, Profile: payloadProfile{Address: payloadAddress{City: "Rio"}}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(person1, person2, WithFieldNaming(JSONNames))
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "id", diffs[0].Path.String())
+	assert.Equal(t, "profile.address.city", diffs[1].Path.String())
+}
+
+func TestFindDifferencesWithOptions_JSONNames_ShouldFallBackAndHonorDiffTag(t *testing.T) {
+	// Arrange
+	person1 := payloadPerson{Name: "Alice", Nickname: "Al"}
+	person2 := payloadPerson{Name: "Bob", Nickname: "Bobby"}
+
+	// Act
+	diffs := FindDifferencesWithOptions(person1, person2, WithFieldNaming(JSONNames))
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "full_name", diffs[0].Path.String(), "The diff tag name takes precedence")
+	assert.Equal(t, "Nickname", diffs[1].Path.String(), "An empty json name falls back to the Go name")
+}
+
+func TestFindDifferencesWithOptions_JSONNames_ShouldSkipDashFields(t *testing.T) {
+	// Arrange
+	person1 := payloadPerson{Password: "secret1"}
+	person2 := payloadPerson{Password: "secret2"}
+
+	// Act
+	withJSON := FindDifferencesWithOptions(person1, person2, WithFieldNaming(JSONNames))
+	withGo := FindDifferences(person1, person2)
+
+	// Assert
+	assert.Empty(t, withJSON)
+	assert.Len(t, withGo, 1)
+	assert.Equal(t, "Password", withGo[0].Path.String())
+}
+
+func TestFindDifferencesWithOptions_JSONNamesOmitEmpty_ShouldTreatNilAndEmptyAsOmitted(t *testing.T) {
+	// Arrange
+	person1 := payloadPerson{Emails: nil}
+	person2 := payloadPerson{Emails: []string{}}
+
+	// Act
+	withJSON := FindDifferencesWithOptions(person1, person2, WithFieldNaming(JSONNames))
+	withGo := FindDifferences(person1, person2)
+
+	// Assert
+	assert.Empty(t, withJSON, "Both values are omitted from the JSON payload")
+	assert.Len(t, withGo, 1)
+}
+
+func TestFindDifferencesWithOptions_JSONNames_ShouldFlattenEmbeddedStructs(t *testing.T) {
+	// Arrange
+	person1 := payloadPerson{PayloadAudit: PayloadAudit{CreatedBy: "alice"}}
+	person2 := payloadPerson{PayloadAudit: PayloadAudit{CreatedBy: "bob"}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(person1, person2, WithFieldNaming(JSONNames))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "created_by", diffs[0].Path.String())
+}
+
+func TestFindDifferencesWithOptions_YAMLNames_ShouldUseYAMLTagsInPaths(t *testing.T) {
+	// Arrange
+	address1 := payloadAddress{City: "São Paulo", Country: "Brasil"}
+	address2 := payloadAddress{City: "Rio", Country: "Brazil"}
+
+	// Act
+	diffs := FindDifferencesWithOptions(address1, address2, WithFieldNaming(YAMLNames))
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "cidade", diffs[0].Path.String())
+	assert.Equal(t, "Country", diffs[1].Path.String())
+}
+
+type payloadItem struct {
+	ID    int    `json:"id"`
+	Value string `json:"v"`
+}
+
+type payloadOrder struct {
+	Items []payloadItem `json:"items"`
+}
+
+func TestFindDifferencesWithOptions_JSONNamesWithSliceKey_ShouldNameKeyStep(t *testing.T) {
+	// Arrange
+	expected := payloadOrder{Items: []payloadItem{{ID: 1, Value: "a"}, {ID: 2, Value: "b"}}}
+	actual := payloadOrder{Items: []payloadItem{{ID: 2, Value: "b"}, {ID: 1, Value: "c"}}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual,
+		WithFieldNaming(JSONNames), WithSliceKey(payloadItem{}, "ID"))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "items.[id=1].v", diffs[0].Path.String())
+
+	value, found := diffs[0].Path.Lookup(actual)
+	assert.True(t, found)
+	assert.Equal(t, "c", value)
+}
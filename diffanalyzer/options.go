@@ -0,0 +1,122 @@
+package diffanalyzer
+
+import (
+	"reflect"
+	"time"
+)
+
+// Option configures how a Differ compares values.
+type Option func(*options)
+
+// options collects every setting consulted by the comparer. Each feature adds
+// its own fields here together with the Option that sets them.
+type options struct {
+	// node holds the defaults for settings that ForPath and ForType can
+	// override on individual values.
+	node nodeOptions
+	// scopes are the ForPath and ForType overrides, in the order given.
+	scopes []scope
+	// naming selects the names used for struct fields in paths.
+	naming FieldNaming
+	// unexported selects how unexported struct fields are handled.
+	unexported UnexportedPolicy
+	// structural allows values of different types with the same shape to be
+	// compared.
+	structural bool
+	// fieldMappings pairs the fields of two struct types compared by name.
+	fieldMappings map[typePair]map[string]string
+	// strictKinds refuses the kinds that can only be compared by identity.
+	strictKinds bool
+	// ignored lists the paths excluded from the comparison.
+	ignored []pathPattern
+	// comparators maps a type to the comparison registered for it.
+	comparators map[reflect.Type]comparator
+	// sliceKeys maps a struct type to the name of its identity field.
+	sliceKeys map[reflect.Type]string
+}
+
+// nodeOptions holds the settings that apply to a single value and can
+// therefore be scoped to a path or a type.
+type nodeOptions struct {
+	unordered    bool
+	compactBytes bool
+	// tolerance bounds the difference under which two floats are considered
+	// equal.
+	tolerance Tolerance
+	// nanEqual treats NaN as equal to NaN.
+	nanEqual bool
+	// timeTruncation, timeTolerance and wallClock configure the comparison
+	// of time.Time and time.Duration values.
+	timeTruncation time.Duration
+	timeTolerance  time.Duration
+	wallClock      bool
+	// nilAsEmpty and nilPointerAsZero treat a nil slice, map or pointer as
+	// equal to an empty one or a pointer to the zero value.
+	nilAsEmpty       bool
+	nilPointerAsZero bool
+	// foldCase, trimSpace, collapseSpace, normalization and regexpMatch
+	// configure the comparison of strings.
+	foldCase      bool
+	trimSpace     bool
+	collapseSpace bool
+	normalization UnicodeNormalization
+	regexpMatch   bool
+	// partial treats zero values in expected as matching anything.
+	partial bool
+	// equalMethods and textComparison compare values through their Equal,
+	// MarshalText or String methods.
+	equalMethods   bool
+	textComparison bool
+}
+
+// scope applies opts to the values it matches.
+type scope struct {
+	matches func(path Path, t reflect.Type) bool
+	opts    []Option
+}
+
+// ForPath applies opts only to the values whose path matches pattern, e.g.
+// ForPath("Profile.Tags", WithUnorderedSlices()) or
+// ForPath("Items.[*].Tags", WithUnorderedSlices()). Only options that affect a
+// single value take effect inside ForPath.
+func ForPath(pattern string, opts ...Option) Option {
+	compiled := compilePattern(pattern)
+	return func(o *options) {
+		o.scopes = append(o.scopes, scope{
+			matches: func(p Path, _ reflect.Type) bool { return compiled.match(p) },
+			opts:    opts,
+		})
+	}
+}
+
+// ForType applies opts only to values of the same type as sample, e.g.
+// ForType([]string{}, WithUnorderedSlices()). Only options that affect a
+// single value take effect inside ForType.
+func ForType(sample interface{}, opts ...Option) Option {
+	t := reflect.TypeOf(sample)
+	return func(o *options) {
+		o.scopes = append(o.scopes, scope{
+			matches: func(_ Path, vt reflect.Type) bool { return vt == t },
+			opts:    opts,
+		})
+	}
+}
+
+// nodeOptions resolves the settings for the value of type t at path: the
+// global defaults, then the modifiers of the enclosing field's tag, then every
+// matching scope.
+func (c *comparer) nodeOptions(path Path, t reflect.Type, tag fieldTag) nodeOptions {
+	node := c.opts.node
+	tag.apply(&node)
+	for _, s := range c.opts.scopes {
+		if !s.matches(path, t) {
+			continue
+		}
+		scoped := options{node: node}
+		for _, opt := range s.opts {
+			opt(&scoped)
+		}
+		node = scoped.node
+	}
+	return node
+}
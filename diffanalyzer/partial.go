@@ -0,0 +1,27 @@
+package diffanalyzer
+
+import "reflect"
+
+// WithPartialMatch lets expectations be written sparsely: zero values in
+// expected (empty strings, zero numbers, nil slices, maps and pointers, zero
+// structs) mean "don't care" and match any actual value, at any depth. Map
+// keys and struct fields found only in actual are ignored too, while
+// elements appended to a slice are still reported. A false or 0 can therefore
+// not be asserted in this mode; compare those fields separately.
+func WithPartialMatch() Option {
+	return func(o *options) {
+		o.node.partial = true
+	}
+}
+
+// dontCare reports whether expected is a zero value ignored by the partial
+// match mode.
+func (c *comparer) dontCare(expected reflect.Value, path Path, tag fieldTag) bool {
+	return expected.IsZero() && c.partial(path, expected.Type(), tag)
+}
+
+// partial reports whether the value of type t at path is compared in the
+// partial match mode.
+func (c *comparer) partial(path Path, t reflect.Type, tag fieldTag) bool {
+	return c.nodeOptions(path, t, tag).partial
+}
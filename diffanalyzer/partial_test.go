@@ -0,0 +1,93 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/seu-usuario/meu-projeto/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var fullPerson = models.Person{
+	ID:     7,
+	Name:   "Alice",
+	Emails: []string{"alice@example.com", "a@example.com"},
+	Profile: models.Profile{
+		Bio:     "Engineer",
+		Tags:    []string{"go", "api"},
+		Address: models.Address{City: "Recife", Country: "BR"},
+	},
+}
+
+func TestFindDifferences_WithPartialMatch_ShouldIgnoreZeroExpectedFields(t *testing.T) {
+	// Arrange
+	expected := models.Person{Name: "Alice", Profile: models.Profile{Address: models.Address{City: "Recife"}}}
+
+	// Act
+	partial := FindDifferencesWithOptions(expected, fullPerson, WithPartialMatch())
+	strict := FindDifferences(expected, fullPerson)
+
+	// Assert
+	assert.Empty(t, partial)
+	assert.NotEmpty(t, strict)
+}
+
+func TestFindDifferences_WithPartialMatch_ShouldReportSetFields(t *testing.T) {
+	// Arrange
+	expected := models.Person{Name: "Bob", Profile: models.Profile{Address: models.Address{Country: "PT"}}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, fullPerson, WithPartialMatch())
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "Name", diffs[0].Path.String())
+	assert.Equal(t, "Profile.Address.Country", diffs[1].Path.String())
+}
+
+func TestFindDifferences_WithPartialMatch_ShouldIgnoreExtraMapKeys(t *testing.T) {
+	// Arrange
+	expected := models.MapContainer{
+		IntMap:    map[string]int{"count": 10},
+		PersonMap: map[string]models.Person{"alice": {Profile: models.Profile{Bio: "Engineer"}}},
+	}
+	actual := models.MapContainer{
+		StringMap: map[string]string{"key": "value"},
+		IntMap:    map[string]int{"count": 10, "total": 100},
+		PersonMap: map[string]models.Person{"alice": fullPerson, "bob": {Name: "Bob"}},
+	}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithPartialMatch())
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFindDifferences_WithPartialMatch_ShouldMatchZeroSliceElements(t *testing.T) {
+	// Arrange
+	expected := models.ItemCollection{Items: []models.Item{{}, {Status: "shipped"}}}
+	actual := models.ItemCollection{Items: []models.Item{{ID: 1, Status: "pending"}, {ID: 2, Status: "shipped"}, {ID: 3}}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithPartialMatch())
+
+	// Assert
+	assert.Len(t, diffs, 1, "Elements appended to a slice are still reported")
+	assert.Equal(t, "Items.[2]", diffs[0].Path.String())
+	assert.Equal(t, Added, diffs[0].Change)
+}
+
+func TestFindDifferences_WithPartialMatchForPath_ShouldOnlyApplyToPath(t *testing.T) {
+	// Arrange
+	expected := models.Person{Name: "Alice", Profile: models.Profile{Bio: "Engineer"}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, fullPerson, ForPath("Profile.**", WithPartialMatch()))
+
+	// Assert
+	var paths []string
+	for _, diff := range diffs {
+		paths = append(paths, diff.Path.String())
+	}
+	assert.Equal(t, []string{"ID", "Emails"}, paths)
+}
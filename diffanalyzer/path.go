@@ -0,0 +1,406 @@
+package diffanalyzer
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// StepKind identifies what a PathStep traverses.
+type StepKind int
+
+const (
+	// FieldStep selects the struct field Name.
+	FieldStep StepKind = iota
+	// IndexStep selects the element at Index of a slice or array.
+	IndexStep
+	// MapKeyStep selects the map entry for Key.
+	MapKeyStep
+	// KeyedStep selects the slice element whose identity field Name equals
+	// Key (see WithSliceKey).
+	KeyedStep
+	// DerefStep follows a pointer. It is not rendered by String, JSONPointer
+	// or JSONPath.
+	DerefStep
+)
+
+// PathStep is a single step of a Path.
+type PathStep struct {
+	Kind  StepKind
+	Name  string
+	Index int
+	Key   interface{}
+}
+
+// Path locates a value inside the compared values as a sequence of steps from
+// the root. The zero Path is the root itself.
+type Path []PathStep
+
+// Field returns p extended with a struct field step.
+func (p Path) Field(name string) Path {
+	return p.with(PathStep{Kind: FieldStep, Name: name})
+}
+
+// Index returns p extended with a slice or array element step.
+func (p Path) Index(i int) Path {
+	return p.with(PathStep{Kind: IndexStep, Index: i})
+}
+
+// MapKey returns p extended with a map entry step.
+func (p Path) MapKey(key interface{}) Path {
+	return p.with(PathStep{Kind: MapKeyStep, Key: key})
+}
+
+// Keyed returns p extended with a keyed slice element step.
+func (p Path) Keyed(field string, key interface{}) Path {
+	return p.with(PathStep{Kind: KeyedStep, Name: field, Key: key})
+}
+
+// Deref returns p extended with a pointer dereference step.
+func (p Path) Deref() Path {
+	return p.with(PathStep{Kind: DerefStep})
+}
+
+// with returns a copy of p with step appended, so that paths sharing a parent
+// never share a backing array.
+func (p Path) with(step PathStep) Path {
+	next := make(Path, len(p), len(p)+1)
+	copy(next, p)
+	return append(next, step)
+}
+
+// String renders p in the dotted form used throughout the package, e.g.
+// "Profile.Address.City", "Emails.[1]", "NestedMap.[group1].[item1]" or
+// "Items.[ID=3].Value". Dots and brackets inside names and keys are escaped
+// with a backslash so that ParsePath can read the result back.
+func (p Path) String() string {
+	return strings.Join(p.segments(), ".")
+}
+
+// segments renders every visible step of p.
+func (p Path) segments() []string {
+	segments := make([]string, 0, len(p))
+	for _, step := range p {
+		switch step.Kind {
+		case FieldStep:
+			segments = append(segments, escape(step.Name, `\.[]`))
+		case IndexStep:
+			segments = append(segments, "["+strconv.Itoa(step.Index)+"]")
+		case MapKeyStep:
+			segments = append(segments, "["+escape(fmt.Sprint(step.Key), `\]=`)+"]")
+		case KeyedStep:
+			segments = append(segments, "["+escape(step.Name, `\]=`)+"="+escape(fmt.Sprint(step.Key), `\]=`)+"]")
+		}
+	}
+	return segments
+}
+
+// JSONPointer renders p as an RFC 6901 JSON Pointer, e.g.
+// "/Profile/Address/City" or "/Emails/1". Keyed steps have no JSON Pointer
+// equivalent and are rendered as "ID=3".
+func (p Path) JSONPointer() string {
+	var b strings.Builder
+	replacer := strings.NewReplacer("~", "~0", "/", "~1")
+	for _, step := range p {
+		var token string
+		switch step.Kind {
+		case FieldStep:
+			token = step.Name
+		case IndexStep:
+			token = strconv.Itoa(step.Index)
+		case MapKeyStep:
+			token = fmt.Sprint(step.Key)
+		case KeyedStep:
+			token = step.Name + "=" + fmt.Sprint(step.Key)
+		default:
+			continue
+		}
+		b.WriteString("/")
+		b.WriteString(replacer.Replace(token))
+	}
+	return b.String()
+}
+
+// JSONPath renders p as a JSONPath expression, e.g.
+// "$.Profile.Address.City", "$.Emails[1]", "$.StringMap['key1']" or
+// "$.Items[?(@.ID==3)].Value".
+func (p Path) JSONPath() string {
+	var b strings.Builder
+	b.WriteString("$")
+	for _, step := range p {
+		switch step.Kind {
+		case FieldStep:
+			if isIdentifier(step.Name) {
+				b.WriteString("." + step.Name)
+			} else {
+				b.WriteString("[" + quoteJSONPath(step.Name) + "]")
+			}
+		case IndexStep:
+			b.WriteString("[" + strconv.Itoa(step.Index) + "]")
+		case MapKeyStep:
+			b.WriteString("[" + quoteJSONPath(fmt.Sprint(step.Key)) + "]")
+		case KeyedStep:
+			key := fmt.Sprint(step.Key)
+			if _, isString := step.Key.(string); isString {
+				key = quoteJSONPath(key)
+			}
+			b.WriteString("[?(@." + step.Name + "==" + key + ")]")
+		}
+	}
+	return b.String()
+}
+
+// ParsePath parses the form produced by Path.String. Bracketed segments made
+// only of digits become IndexSteps, "[Name=Key]" becomes a KeyedStep and any
+// other bracketed segment a MapKeyStep with a string Key. DerefSteps are not
+// rendered and therefore never produced.
+func ParsePath(s string) (Path, error) {
+	segments, err := splitPath(s)
+	if err != nil {
+		return nil, err
+	}
+
+	path := make(Path, 0, len(segments))
+	for _, segment := range segments {
+		if !strings.HasPrefix(segment, "[") {
+			path = append(path, PathStep{Kind: FieldStep, Name: unescape(segment)})
+			continue
+		}
+
+		content := segment[1 : len(segment)-1]
+		if name, key, ok := cutUnescaped(content, '='); ok {
+			path = append(path, PathStep{Kind: KeyedStep, Name: unescape(name), Key: unescape(key)})
+			continue
+		}
+		if index, err := strconv.Atoi(content); err == nil && index >= 0 && !strings.HasPrefix(content, "+") {
+			path = append(path, PathStep{Kind: IndexStep, Index: index})
+			continue
+		}
+		path = append(path, PathStep{Kind: MapKeyStep, Key: unescape(content)})
+	}
+	return path, nil
+}
+
+// Lookup follows p from root and returns the value found there. Pointers and
+// interfaces are followed implicitly. Map keys and keyed elements are matched
+// by their printed form, so paths obtained from ParsePath work as well.
+func (p Path) Lookup(root interface{}) (interface{}, bool) {
+	v := reflect.ValueOf(root)
+	for _, step := range p {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, false
+			}
+			v = v.Elem()
+		}
+
+		switch step.Kind {
+		case DerefStep:
+			continue
+		case FieldStep:
+			if v.Kind() != reflect.Struct {
+				return nil, false
+			}
+			v = lookupField(v, step.Name)
+		case IndexStep:
+			switch v.Kind() {
+			case reflect.Slice, reflect.Array:
+				if step.Index >= v.Len() {
+					return nil, false
+				}
+				v = v.Index(step.Index)
+			case reflect.Map:
+				v = lookupMapKey(v, step.Index)
+			default:
+				return nil, false
+			}
+		case MapKeyStep:
+			if v.Kind() != reflect.Map {
+				return nil, false
+			}
+			v = lookupMapKey(v, step.Key)
+		case KeyedStep:
+			if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+				return nil, false
+			}
+			v = lookupKeyed(v, step.Name, step.Key)
+		}
+
+		if !v.IsValid() {
+			return nil, false
+		}
+	}
+
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// lookupField finds a struct field by its Go name, its `diff` tag name or its
+// `json`/`yaml` tag name.
+func lookupField(v reflect.Value, name string) reflect.Value {
+	if field := v.FieldByName(name); field.IsValid() {
+		return field
+	}
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		for _, naming := range []FieldNaming{GoNames, JSONNames, YAMLNames} {
+			if encoded := naming.encode(field, parseFieldTag(field)); !encoded.skip && encoded.name == name {
+				return v.Field(i)
+			}
+		}
+	}
+	return reflect.Value{}
+}
+
+func lookupMapKey(m reflect.Value, key interface{}) reflect.Value {
+	k := reflect.ValueOf(key)
+	if k.IsValid() && k.Type().AssignableTo(m.Type().Key()) {
+		if value := m.MapIndex(k); value.IsValid() {
+			return value
+		}
+	}
+	printed := fmt.Sprint(key)
+	for _, candidate := range m.MapKeys() {
+		if fmt.Sprint(candidate) == printed {
+			return m.MapIndex(candidate)
+		}
+	}
+	return reflect.Value{}
+}
+
+func lookupKeyed(s reflect.Value, field string, key interface{}) reflect.Value {
+	printed := fmt.Sprint(key)
+	for i := range s.Len() {
+		elem := s.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+		k := lookupField(elem, field)
+		if k.IsValid() && k.CanInterface() && fmt.Sprint(k.Interface()) == printed {
+			return s.Index(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// splitPath splits a rendered path into its raw, still escaped, segments.
+// Dots inside brackets belong to the map key and do not split the path, and
+// a bracket directly after a name ("Emails[1]") starts a new segment.
+func splitPath(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	var segments []string
+	var current strings.Builder
+	inBracket := false
+	for i := 0; i < len(path); i++ {
+		ch := path[i]
+		switch {
+		case ch == '\\':
+			if i+1 == len(path) {
+				return nil, fmt.Errorf("diffanalyzer: path %q ends with an escape character", path)
+			}
+			current.WriteByte(ch)
+			current.WriteByte(path[i+1])
+			i++
+		case inBracket:
+			current.WriteByte(ch)
+			if ch == ']' {
+				inBracket = false
+				segments = append(segments, current.String())
+				current.Reset()
+				if i+1 < len(path) && path[i+1] == '.' {
+					i++
+				}
+			}
+		case ch == '[':
+			if current.Len() > 0 {
+				segments = append(segments, current.String())
+				current.Reset()
+			}
+			inBracket = true
+			current.WriteByte(ch)
+		case ch == '.':
+			segments = append(segments, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(ch)
+		}
+	}
+	if inBracket {
+		return nil, fmt.Errorf("diffanalyzer: path %q has an unterminated bracket", path)
+	}
+	if current.Len() > 0 || strings.HasSuffix(path, ".") {
+		segments = append(segments, current.String())
+	}
+	return segments, nil
+}
+
+// escape prefixes every character of s found in special with a backslash.
+func escape(s, special string) string {
+	if !strings.ContainsAny(s, special) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(special, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// cutUnescaped splits s around the first sep not preceded by a backslash.
+func cutUnescaped(s string, sep byte) (before, after string, found bool) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func quoteJSONPath(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
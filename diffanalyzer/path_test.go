@@ -0,0 +1,177 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/seu-usuario/meu-projeto/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPath_Renderings(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        Path
+		str         string
+		jsonPointer string
+		jsonPath    string
+	}{
+		{
+			"root",
+			nil,
+			"",
+			"",
+			"$",
+		},
+		{
+			"nested fields",
+			Path{}.Field("Profile").Field("Address").Field("City"),
+			"Profile.Address.City",
+			"/Profile/Address/City",
+			"$.Profile.Address.City",
+		},
+		{
+			"slice element",
+			Path{}.Field("Emails").Index(1),
+			"Emails.[1]",
+			"/Emails/1",
+			"$.Emails[1]",
+		},
+		{
+			"nested map keys",
+			Path{}.Field("NestedMap").MapKey("group1").MapKey("item1"),
+			"NestedMap.[group1].[item1]",
+			"/NestedMap/group1/item1",
+			"$.NestedMap['group1']['item1']",
+		},
+		{
+			"keyed element",
+			Path{}.Field("Items").Keyed("ID", 3).Field("Value"),
+			"Items.[ID=3].Value",
+			"/Items/ID=3/Value",
+			"$.Items[?(@.ID==3)].Value",
+		},
+		{
+			"map key with special characters",
+			Path{}.Field("StringMap").MapKey("a.b/c]=d~e"),
+			`StringMap.[a.b/c\]\=d~e]`,
+			"/StringMap/a.b~1c]=d~0e",
+			"$.StringMap['a.b/c]=d~e']",
+		},
+		{
+			"dereferenced pointer",
+			Path{}.Field("Address").Deref().Field("City"),
+			"Address.City",
+			"/Address/City",
+			"$.Address.City",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.str, test.path.String())
+			assert.Equal(t, test.jsonPointer, test.path.JSONPointer())
+			assert.Equal(t, test.jsonPath, test.path.JSONPath())
+		})
+	}
+}
+
+func TestParsePath_ShouldRoundTripString(t *testing.T) {
+	paths := []string{
+		"",
+		"Name",
+		"Profile.Address.City",
+		"Emails.[1]",
+		"[1].Value",
+		"NestedMap.[group1].[item1]",
+		"Items.[ID=3].Value",
+		`StringMap.[a.b/c\]\=d]`,
+		`weird\.field.[x]`,
+	}
+
+	for _, str := range paths {
+		t.Run(str, func(t *testing.T) {
+			path, err := ParsePath(str)
+			assert.NoError(t, err)
+			assert.Equal(t, str, path.String())
+		})
+	}
+}
+
+func TestParsePath_ShouldBuildStructuredSteps(t *testing.T) {
+	// Act
+	path, err := ParsePath(`Items.[ID=3].Tags[0].[a\.b]`)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, Path{
+		{Kind: FieldStep, Name: "Items"},
+		{Kind: KeyedStep, Name: "ID", Key: "3"},
+		{Kind: FieldStep, Name: "Tags"},
+		{Kind: IndexStep, Index: 0},
+		{Kind: MapKeyStep, Key: "a.b"},
+	}, path)
+}
+
+func TestParsePath_Malformed_ShouldReturnError(t *testing.T) {
+	_, err := ParsePath("Items.[3")
+	assert.Error(t, err)
+
+	_, err = ParsePath(`Name\`)
+	assert.Error(t, err)
+}
+
+func TestPath_Lookup(t *testing.T) {
+	// Arrange
+	container := models.MapContainer{
+		NestedMap: map[string]map[string]int{"group1": {"item1": 10}},
+		PersonMap: map[string]models.Person{
+			"employee1": {Name: "John", Emails: []string{"john@x.com"}},
+		},
+	}
+	collection := &models.ItemCollection{Items: []models.Item{{ID: 3, Value: 150}}}
+
+	tests := []struct {
+		root     interface{}
+		path     string
+		expected interface{}
+	}{
+		{container, "NestedMap.[group1].[item1]", 10},
+		{container, "PersonMap.[employee1].Emails.[0]", "john@x.com"},
+		{collection, "Items.[ID=3].Value", 150},
+		{collection, "Items.[0].ID", 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			path, err := ParsePath(test.path)
+			assert.NoError(t, err)
+
+			value, found := path.Lookup(test.root)
+
+			assert.True(t, found)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+
+	_, found := Path{}.Field("Missing").Lookup(container)
+	assert.False(t, found)
+}
+
+func TestFindDifferences_ReportedPaths_ShouldBeStructured(t *testing.T) {
+	// Arrange
+	type Node struct {
+		Next  *Node
+		Value int
+	}
+	expected := Node{Next: &Node{Value: 1}}
+	actual := Node{Next: &Node{Value: 2}}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, Path{}.Field("Next").Deref().Field("Value"), diffs[0].Path)
+	assert.Equal(t, "Next.Value", diffs[0].Path.String())
+	assert.Equal(t, "/Next/Value", diffs[0].Path.JSONPointer())
+}
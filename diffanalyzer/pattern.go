@@ -0,0 +1,65 @@
+package diffanalyzer
+
+import "strings"
+
+// pathPattern is a path whose segments may contain wildcards:
+//
+//   - "*" inside a segment matches any run of characters within that segment,
+//     so "*" matches any single segment and "[*]" any index or map key;
+//   - a "**" segment matches any number of segments, including none.
+//
+// Segments are compared in their rendered, escaped form (see Path.String).
+// For example "*.ID" matches "Profile.ID", "Items.[*].Value" matches
+// "Items.[3].Value" and "**.UpdatedAt" matches "UpdatedAt" at any depth.
+type pathPattern []string
+
+// compilePattern splits pattern into segments. Like regexp.MustCompile it
+// panics on a malformed pattern, since patterns are fixed by the caller.
+func compilePattern(pattern string) pathPattern {
+	segments, err := splitPath(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return pathPattern(segments)
+}
+
+func (p pathPattern) match(path Path) bool {
+	return matchSegments(p, path.segments())
+}
+
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 || !matchSegment(pattern[0], segments[0]) {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// matchSegment matches a single segment against a pattern in which "*"
+// stands for any run of characters.
+func matchSegment(pattern, segment string) bool {
+	star := strings.IndexByte(pattern, '*')
+	if star < 0 {
+		return pattern == segment
+	}
+	if !strings.HasPrefix(segment, pattern[:star]) {
+		return false
+	}
+	rest := pattern[star+1:]
+	for i := star; i <= len(segment); i++ {
+		if matchSegment(rest, segment[i:]) {
+			return true
+		}
+	}
+	return false
+}
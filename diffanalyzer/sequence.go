@@ -0,0 +1,254 @@
+package diffanalyzer
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+type editKind int
+
+const (
+	editEqual editKind = iota
+	editDelete
+	editInsert
+)
+
+// edit is one step of an alignment between two sequences. expectedIndex is
+// meaningful for editEqual and editDelete, actualIndex for editEqual and
+// editInsert.
+type edit struct {
+	kind          editKind
+	expectedIndex int
+	actualIndex   int
+}
+
+// maxAlignmentCells bounds the size of the table alignSequences builds for
+// the part of two sequences left once their common prefix and suffix are
+// matched. Beyond it the remaining elements are paired by position.
+const maxAlignmentCells = 1 << 22
+
+// alignSequences aligns n expected elements with m actual elements using the
+// longest common subsequence, returning the edits in order. equal must be
+// cheap, as it is called for every pair of elements. Common prefixes and
+// suffixes are matched directly so that appends and truncations of long
+// sequences stay cheap.
+func alignSequences(n, m int, equal func(i, j int) bool) []edit {
+	var edits []edit
+
+	prefix := 0
+	for prefix < n && prefix < m && equal(prefix, prefix) {
+		edits = append(edits, edit{kind: editEqual, expectedIndex: prefix, actualIndex: prefix})
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < n-prefix && suffix < m-prefix && equal(n-1-suffix, m-1-suffix) {
+		suffix++
+	}
+
+	rows, cols := n-prefix-suffix, m-prefix-suffix
+	if rows*cols > maxAlignmentCells {
+		for i := range rows {
+			edits = append(edits, edit{kind: editDelete, expectedIndex: prefix + i})
+		}
+		for j := range cols {
+			edits = append(edits, edit{kind: editInsert, actualIndex: prefix + j})
+		}
+	} else {
+		lcs := make([][]int32, rows+1)
+		for i := range lcs {
+			lcs[i] = make([]int32, cols+1)
+		}
+		for i := rows - 1; i >= 0; i-- {
+			for j := cols - 1; j >= 0; j-- {
+				if equal(prefix+i, prefix+j) {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < rows || j < cols {
+			switch {
+			case i < rows && j < cols && equal(prefix+i, prefix+j):
+				edits = append(edits, edit{kind: editEqual, expectedIndex: prefix + i, actualIndex: prefix + j})
+				i++
+				j++
+			case j == cols || (i < rows && lcs[i+1][j] >= lcs[i][j+1]):
+				edits = append(edits, edit{kind: editDelete, expectedIndex: prefix + i})
+				i++
+			default:
+				edits = append(edits, edit{kind: editInsert, actualIndex: prefix + j})
+				j++
+			}
+		}
+	}
+
+	for k := suffix; k > 0; k-- {
+		edits = append(edits, edit{kind: editEqual, expectedIndex: n - k, actualIndex: m - k})
+	}
+	return edits
+}
+
+// compareSequence reports the differences between two slices element by
+// element, aligning them on deep equality. Deletions and insertions that fall
+// in the same gap of the alignment are paired up and compared in place, so a
+// changed element is reported as a modification of its fields rather than a
+// removal plus an addition.
+func (c *comparer) compareSequence(expected, actual reflect.Value, path Path, tag fieldTag) {
+	expectedClasses, actualClasses := elementClasses(expected, actual)
+	edits := alignSequences(expected.Len(), actual.Len(), func(i, j int) bool {
+		return expectedClasses[i] == actualClasses[j]
+	})
+
+	var deleted, inserted []int
+	flush := func() {
+		paired := min(len(deleted), len(inserted))
+		for k := range paired {
+			c.compare(expected.Index(deleted[k]), actual.Index(inserted[k]), path.Index(deleted[k]), tag)
+		}
+		for _, i := range deleted[paired:] {
+			c.report(FieldDiff{
+				Path:     path.Index(i),
+				Expected: reportedValue(expected.Index(i)),
+				Actual:   nil,
+				Change:   Removed,
+			})
+		}
+		for _, j := range inserted[paired:] {
+			c.report(FieldDiff{
+				Path:     path.Index(j),
+				Expected: nil,
+				Actual:   reportedValue(actual.Index(j)),
+				Change:   Added,
+			})
+		}
+		deleted, inserted = deleted[:0], inserted[:0]
+	}
+
+	for _, e := range edits {
+		switch e.kind {
+		case editDelete:
+			deleted = append(deleted, e.expectedIndex)
+		case editInsert:
+			inserted = append(inserted, e.actualIndex)
+		default:
+			flush()
+		}
+	}
+	flush()
+}
+
+// maxHashDepth bounds how deep hashValue descends into a value, which keeps
+// it finite on cyclic values. Deeper differences only make more elements
+// share a bucket.
+const maxHashDepth = 8
+
+// elementClasses assigns every element of expected and actual a class, such
+// that two elements share a class exactly when they are deeply equal.
+// Elements are bucketed by a hash, so each one is deep-compared only with the
+// representatives of its own bucket.
+func elementClasses(expected, actual reflect.Value) (expectedClasses, actualClasses []int) {
+	type representative struct {
+		value reflect.Value
+		class int
+	}
+	seed := maphash.MakeSeed()
+	buckets := make(map[uint64][]representative)
+	classes := 0
+
+	classify := func(v reflect.Value) int {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		hashValue(&h, v, 0)
+		sum := h.Sum64()
+		for _, r := range buckets[sum] {
+			if deepEqual(r.value, v) {
+				return r.class
+			}
+		}
+		buckets[sum] = append(buckets[sum], representative{value: v, class: classes})
+		classes++
+		return classes - 1
+	}
+
+	expectedClasses = make([]int, expected.Len())
+	for i := range expectedClasses {
+		expectedClasses[i] = classify(expected.Index(i))
+	}
+	actualClasses = make([]int, actual.Len())
+	for j := range actualClasses {
+		actualClasses[j] = classify(actual.Index(j))
+	}
+	return expectedClasses, actualClasses
+}
+
+// hashValue writes to h a hash of v such that deeply equal values hash alike.
+func hashValue(h *maphash.Hash, v reflect.Value, depth int) {
+	if !v.IsValid() {
+		h.WriteByte(0)
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(h, real(v.Complex()))
+		writeFloat(h, imag(v.Complex()))
+	case reflect.String:
+		h.WriteString(v.String())
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		writeUint64(h, uint64(v.Pointer()))
+	}
+
+	if depth == maxHashDepth {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			hashValue(h, v.Elem(), depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		writeUint64(h, uint64(v.Len()))
+		for i := range v.Len() {
+			hashValue(h, v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		// Map iteration order is random, so only the size is hashed.
+		writeUint64(h, uint64(v.Len()))
+	case reflect.Struct:
+		for i := range v.NumField() {
+			hashValue(h, v.Field(i), depth+1)
+		}
+	}
+}
+
+func writeUint64(h *maphash.Hash, n uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], n)
+	h.Write(b[:])
+}
+
+// writeFloat hashes f so that 0 and -0, which are equal, hash alike.
+func writeFloat(h *maphash.Hash, f float64) {
+	if f == 0 {
+		f = 0
+	}
+	writeUint64(h, math.Float64bits(f))
+}
@@ -0,0 +1,133 @@
+package diffanalyzer
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// UnicodeNormalization selects the Unicode normalization form strings are
+// brought to before being compared.
+type UnicodeNormalization int
+
+const (
+	// NoNormalization compares strings byte by byte.
+	NoNormalization UnicodeNormalization = iota
+	// NFC composes characters, so "São" typed with a combining tilde equals
+	// "São" typed with a precomposed "ã".
+	NFC
+	// NFKC also folds compatibility characters, e.g. "ﬁ" to "fi" and
+	// full-width digits to ASCII ones.
+	NFKC
+)
+
+// WithCaseInsensitive compares strings under Unicode case folding.
+func WithCaseInsensitive() Option {
+	return func(o *options) {
+		o.node.foldCase = true
+	}
+}
+
+// WithTrimSpace ignores leading and trailing whitespace in strings.
+func WithTrimSpace() Option {
+	return func(o *options) {
+		o.node.trimSpace = true
+	}
+}
+
+// WithCollapseSpace ignores leading and trailing whitespace in strings and
+// treats every run of whitespace inside them as a single space.
+func WithCollapseSpace() Option {
+	return func(o *options) {
+		o.node.collapseSpace = true
+	}
+}
+
+// WithUnicodeNormalization normalizes strings to form before comparing them.
+func WithUnicodeNormalization(form UnicodeNormalization) Option {
+	return func(o *options) {
+		o.node.normalization = form
+	}
+}
+
+// WithRegexpMatch treats expected strings as regular expressions the whole
+// actual string must match, e.g. ForPath("ID", WithRegexpMatch()) with an
+// expected ID of `[0-9a-f]{8}-.*`. The other string options still apply to
+// the actual string, and WithCaseInsensitive makes the match case
+// insensitive. An expected string that is not a valid expression is compared
+// literally.
+func WithRegexpMatch() Option {
+	return func(o *options) {
+		o.node.regexpMatch = true
+	}
+}
+
+// compareStrings compares two strings under the string options of their
+// node. Differences hold the original strings.
+func (c *comparer) compareStrings(expected, actual reflect.Value, path Path, tag fieldTag) {
+	node := c.nodeOptions(path, expected.Type(), tag)
+	if !c.stringsEqual(node, expected.String(), actual.String()) {
+		c.report(FieldDiff{
+			Path:     path,
+			Expected: expected.String(),
+			Actual:   actual.String(),
+			Change:   Modified,
+		})
+	}
+}
+
+// stringsEqual reports whether expected and actual are equal, or whether
+// actual matches the expression expected, under the string options of node.
+func (c *comparer) stringsEqual(node nodeOptions, expected, actual string) bool {
+	actual = node.normalizeString(actual)
+	if node.regexpMatch {
+		pattern := "^(?:" + expected + ")$"
+		if node.foldCase {
+			pattern = "(?i)" + pattern
+		}
+		if re := c.compilePattern(pattern); re != nil {
+			return re.MatchString(actual)
+		}
+	}
+
+	expected = node.normalizeString(expected)
+	if node.foldCase {
+		return strings.EqualFold(expected, actual)
+	}
+	return expected == actual
+}
+
+// compilePattern compiles the regular expression pattern once per
+// comparison run, returning nil when it is not valid.
+func (c *comparer) compilePattern(pattern string) *regexp.Regexp {
+	if re, cached := c.patterns[pattern]; cached {
+		return re
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		c.patterns[pattern] = nil
+		return nil
+	}
+	c.patterns[pattern] = re
+	return re
+}
+
+// normalizeString applies the Unicode normalization and whitespace options
+// to s.
+func (node nodeOptions) normalizeString(s string) string {
+	switch node.normalization {
+	case NFC:
+		s = norm.NFC.String(s)
+	case NFKC:
+		s = norm.NFKC.String(s)
+	}
+	if node.collapseSpace {
+		return strings.Join(strings.Fields(s), " ")
+	}
+	if node.trimSpace {
+		return strings.TrimSpace(s)
+	}
+	return s
+}
@@ -0,0 +1,119 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/seu-usuario/meu-projeto/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	saoPauloNFC = "São Paulo"
+	saoPauloNFD = "São Paulo"
+)
+
+func TestFindDifferences_StringsByDefault_ShouldCompareBytes(t *testing.T) {
+	// Arrange
+	expected := []string{saoPauloNFC, "Recife", "Natal"}
+	actual := []string{saoPauloNFD, "recife", "Natal "}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 3)
+}
+
+func TestFindDifferences_WithUnicodeNormalization_ShouldMatchEquivalentForms(t *testing.T) {
+	// Arrange
+	expected := models.Address{City: saoPauloNFC, Country: "ﬁnland"}
+	actual := models.Address{City: saoPauloNFD, Country: "finland"}
+
+	// Act
+	nfc := FindDifferencesWithOptions(expected, actual, WithUnicodeNormalization(NFC))
+	nfkc := FindDifferencesWithOptions(expected, actual, WithUnicodeNormalization(NFKC))
+
+	// Assert
+	assert.Len(t, nfc, 1)
+	assert.Equal(t, "Country", nfc[0].Path.String())
+	assert.Empty(t, nfkc)
+}
+
+func TestFindDifferences_WithCaseInsensitive_ShouldFoldCase(t *testing.T) {
+	// Arrange
+	expected := models.Address{City: "RECIFE", Country: "BR"}
+	actual := models.Address{City: "recife", Country: "PT"}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithCaseInsensitive())
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Country", diffs[0].Path.String())
+	assert.Equal(t, "BR", diffs[0].Expected)
+	assert.Equal(t, "PT", diffs[0].Actual)
+}
+
+func TestFindDifferences_WithTrimAndCollapseSpace_ShouldIgnoreWhitespace(t *testing.T) {
+	// Arrange
+	expected := models.Profile{Bio: "Go  developer", Tags: []string{"api"}}
+	actual := models.Profile{Bio: " Go developer\n", Tags: []string{"api\t"}}
+
+	// Act
+	trimmed := FindDifferencesWithOptions(expected, actual, WithTrimSpace())
+	collapsed := FindDifferencesWithOptions(expected, actual, WithCollapseSpace())
+
+	// Assert
+	assert.Len(t, trimmed, 1)
+	assert.Equal(t, "Bio", trimmed[0].Path.String())
+	assert.Empty(t, collapsed)
+}
+
+func TestFindDifferences_WithRegexpMatch_ShouldMatchExpectedPattern(t *testing.T) {
+	// Arrange
+	expected := models.Person{Name: "Ali.*", Emails: []string{`[a-z]+@example\.com`}, Profile: models.Profile{Bio: "[invalid"}}
+	actual := models.Person{Name: "Alice", Emails: []string{"alice@example.org"}, Profile: models.Profile{Bio: "[other"}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithRegexpMatch())
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "Emails.[0]", diffs[0].Path.String())
+	assert.Equal(t, `[a-z]+@example\.com`, diffs[0].Expected)
+	assert.Equal(t, "alice@example.org", diffs[0].Actual)
+	assert.Equal(t, "Profile.Bio", diffs[1].Path.String(), "An invalid expression is compared literally")
+}
+
+func TestFindDifferences_StringOptionsForPath_ShouldOnlyApplyToPath(t *testing.T) {
+	// Arrange
+	expected := models.Person{Name: "alice", Profile: models.Profile{Bio: "dev", Address: models.Address{City: "^Rec"}}}
+	actual := models.Person{Name: "Alice", Profile: models.Profile{Bio: "DEV", Address: models.Address{City: "RECIFE"}}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual,
+		ForPath("Name", WithCaseInsensitive()),
+		ForPath("Profile.Address.City", WithRegexpMatch(), WithCaseInsensitive()),
+	)
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "Profile.Bio", diffs[0].Path.String())
+	assert.Equal(t, "Profile.Address.City", diffs[1].Path.String(), "The match must cover the whole string")
+}
+
+func TestFindDifferences_WithRegexpMatchInUnorderedSlice_ShouldMatchEachPatternOnce(t *testing.T) {
+	// Arrange
+	expected := []string{`user-\d+`, `user-\d+`, `admin-\d+`}
+	actual := []string{"admin-1", "user-2", "guest-3"}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithRegexpMatch(), WithUnorderedSlices())
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "[1]", diffs[0].Path.String())
+	assert.Equal(t, Removed, diffs[0].Change)
+	assert.Equal(t, "[2]", diffs[1].Path.String())
+	assert.Equal(t, Added, diffs[1].Change)
+}
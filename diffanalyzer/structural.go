@@ -0,0 +1,137 @@
+package diffanalyzer
+
+import "reflect"
+
+// WithStructuralTypes allows values of different types to be compared when
+// they have the same shape. Structs of different types are matched field by
+// field by name, fields found on one side only being reported as Added or
+// Removed; slices, pointers, arrays of the same length and maps with the
+// same key type are compared element by element; named types are compared with their
+// underlying type and numbers with numbers of the same family (int with
+// int32, float32 with float64). Without it, values of different types are
+// reported as a single TypeChanged difference and not traversed.
+func WithStructuralTypes() Option {
+	return func(o *options) {
+		o.structural = true
+	}
+}
+
+// typePair identifies the types of the expected and actual values.
+type typePair struct {
+	expected, actual reflect.Type
+}
+
+// WithFieldMapping compares structs of the types of expected and actual field
+// by field, pairing the fields named in mapping (Go field names, expected to
+// actual) and the remaining ones by name, e.g.
+// WithFieldMapping(models.Pessoa{}, models.Person{}, map[string]string{"Nome": "Name"}).
+// Differences are reported under the expected field names. Nested values of
+// different types still require their own mapping or WithStructuralTypes.
+func WithFieldMapping(expected, actual interface{}, mapping map[string]string) Option {
+	pair := typePair{expected: structType(expected), actual: structType(actual)}
+	return func(o *options) {
+		if o.fieldMappings == nil {
+			o.fieldMappings = make(map[typePair]map[string]string)
+		}
+		o.fieldMappings[pair] = mapping
+	}
+}
+
+// structType returns the type of sample, dereferencing a pointer sample.
+func structType(sample interface{}) reflect.Type {
+	t := reflect.TypeOf(sample)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// sameShape reports whether values of the distinct types expected and actual
+// may still be compared, either through a field mapping or under the
+// structural mode.
+func (c *comparer) sameShape(expected, actual reflect.Type) bool {
+	if _, mapped := c.opts.fieldMappings[typePair{expected, actual}]; mapped {
+		return true
+	}
+	if !c.opts.structural || kindFamily(expected.Kind()) != kindFamily(actual.Kind()) {
+		return false
+	}
+	switch expected.Kind() {
+	case reflect.Array:
+		return expected.Len() == actual.Len()
+	case reflect.Map:
+		return expected.Key() == actual.Key()
+	case reflect.Struct:
+		return !c.opts.unexported.isOpaque(expected) && !c.opts.unexported.isOpaque(actual)
+	}
+	return true
+}
+
+// kindFamily groups the sized variants of the numeric kinds, whose values
+// are read through the same reflect accessor.
+func kindFamily(k reflect.Kind) reflect.Kind {
+	switch k {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint
+	case reflect.Float32:
+		return reflect.Float64
+	case reflect.Complex64:
+		return reflect.Complex128
+	}
+	return k
+}
+
+// compareStructsByName compares two structs of different types, pairing
+// their fields through the field mapping or by the name they would have in
+// a path. Fields without a counterpart are reported as Removed or Added.
+func (c *comparer) compareStructsByName(expected, actual reflect.Value, path Path) {
+	mapping := c.opts.fieldMappings[typePair{expected.Type(), actual.Type()}]
+
+	actualFields := c.comparedFields(actual.Type())
+	byGoName := make(map[string]int, len(actualFields))
+	byName := make(map[string]int, len(actualFields))
+	for i, field := range actualFields {
+		byGoName[actual.Type().Field(field.index).Name] = i
+		byName[field.encoded.name] = i
+	}
+
+	matched := make([]bool, len(actualFields))
+	for _, field := range c.comparedFields(expected.Type()) {
+		i, found := byName[field.encoded.name]
+		if target, mapped := mapping[expected.Type().Field(field.index).Name]; mapped {
+			i, found = byGoName[target]
+		}
+		if !found || matched[i] {
+			c.reportOneSided(expected.Field(field.index), path, field, true)
+			continue
+		}
+		matched[i] = true
+		c.compareField(expected.Field(field.index), actual.Field(actualFields[i].index), path, field)
+	}
+
+	if c.partial(path, expected.Type(), fieldTag{}) {
+		return
+	}
+	for i, field := range actualFields {
+		if !matched[i] {
+			c.reportOneSided(actual.Field(field.index), path, field, false)
+		}
+	}
+}
+
+// reportOneSided reports a field that exists only in the expected struct
+// (inExpected) or only in the actual one.
+func (c *comparer) reportOneSided(value reflect.Value, path Path, field structField, inExpected bool) {
+	if field.encoded.omitEmpty && isEmptyValue(value) {
+		return
+	}
+	diff := FieldDiff{Path: path.Field(field.encoded.name), Change: presenceChange(inExpected)}
+	if inExpected {
+		diff.Expected = reportedValue(value)
+	} else {
+		diff.Actual = reportedValue(value)
+	}
+	c.report(diff)
+}
@@ -0,0 +1,218 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/seu-usuario/meu-projeto/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type celsius float64
+
+type reading struct {
+	Sensor string
+	Value  float64
+}
+
+type readingDTO struct {
+	Sensor string
+	Value  float64
+	Unit   string
+}
+
+type readingCelsius struct {
+	Sensor string
+	Value  celsius
+}
+
+type personDTO struct {
+	Nome    string
+	Idade   int32
+	Emails  []string
+	Profile profileDTO
+}
+
+type profileDTO struct {
+	Bio     string
+	Address models.Address
+}
+
+func TestFindDifferences_DifferentStructTypes_ShouldReportTypeNames(t *testing.T) {
+	// Act
+	diffs := FindDifferences(models.Person{Name: "Alice"}, models.Pessoa{Nome: "Alice"})
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "", diffs[0].Path.String())
+	assert.Equal(t, TypeChanged, diffs[0].Change)
+	assert.Equal(t, "models.Person", diffs[0].Expected)
+	assert.Equal(t, "models.Pessoa", diffs[0].Actual)
+}
+
+func TestFindDifferences_DifferentKinds_ShouldReportTypeNames(t *testing.T) {
+	// Arrange
+	expected := map[string]interface{}{"count": 1}
+	actual := map[string]interface{}{"count": int32(1)}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "[count]", diffs[0].Path.String())
+	assert.Equal(t, TypeChanged, diffs[0].Change)
+	assert.Equal(t, "int", diffs[0].Expected)
+	assert.Equal(t, "int32", diffs[0].Actual)
+}
+
+func TestFindDifferences_NamedTypeWithoutStructural_ShouldNotRecurse(t *testing.T) {
+	// Arrange
+	expected := reading{Sensor: "a", Value: 1}
+	actual := readingDTO{Sensor: "b", Value: 2}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, TypeChanged, diffs[0].Change)
+	assert.Equal(t, "diffanalyzer.reading", diffs[0].Expected)
+	assert.Equal(t, "diffanalyzer.readingDTO", diffs[0].Actual)
+}
+
+func TestFindDifferences_StructuralTypes_ShouldMatchFieldsByName(t *testing.T) {
+	// Arrange
+	expected := reading{Sensor: "a", Value: 1}
+	actual := readingDTO{Sensor: "a", Value: 2, Unit: "C"}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithStructuralTypes())
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "Value", diffs[0].Path.String())
+	assert.Equal(t, Modified, diffs[0].Change)
+	assert.Equal(t, 1.0, diffs[0].Expected)
+	assert.Equal(t, 2.0, diffs[0].Actual)
+
+	assert.Equal(t, "Unit", diffs[1].Path.String())
+	assert.Equal(t, Added, diffs[1].Change)
+	assert.Nil(t, diffs[1].Expected)
+	assert.Equal(t, "C", diffs[1].Actual)
+}
+
+func TestFindDifferences_StructuralTypes_ShouldCompareNamedTypesByUnderlyingKind(t *testing.T) {
+	// Arrange
+	expected := []reading{{Sensor: "a", Value: 21.5}}
+	actual := []readingCelsius{{Sensor: "a", Value: 21.5}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithStructuralTypes())
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFindDifferences_StructuralTypes_ShouldStillReportDifferentKinds(t *testing.T) {
+	// Arrange
+	expected := map[string]interface{}{"count": 1}
+	actual := map[string]interface{}{"count": "1"}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithStructuralTypes())
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, TypeChanged, diffs[0].Change)
+	assert.Equal(t, "int", diffs[0].Expected)
+	assert.Equal(t, "string", diffs[0].Actual)
+}
+
+func TestFindDifferences_FieldMapping_ShouldPairMappedFields(t *testing.T) {
+	// Arrange
+	pessoa := models.Pessoa{Nome: "Alice", Idade: 30, Ativo: true, Emails: []string{"a@x.com"}}
+	person := models.Person{ID: 1, Name: "Alicia", Emails: []string{"a@x.com"}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(pessoa, person,
+		WithFieldMapping(models.Pessoa{}, models.Person{}, map[string]string{"Nome": "Name"}))
+
+	// Assert
+	assert.Len(t, diffs, 5)
+	assert.Equal(t, "Nome", diffs[0].Path.String())
+	assert.Equal(t, Modified, diffs[0].Change)
+	assert.Equal(t, "Alice", diffs[0].Expected)
+	assert.Equal(t, "Alicia", diffs[0].Actual)
+
+	assert.Equal(t, "Idade", diffs[1].Path.String())
+	assert.Equal(t, Removed, diffs[1].Change)
+	assert.Equal(t, 30, diffs[1].Expected)
+
+	assert.Equal(t, "Ativo", diffs[2].Path.String())
+	assert.Equal(t, Removed, diffs[2].Change)
+
+	assert.Equal(t, "ID", diffs[3].Path.String())
+	assert.Equal(t, Added, diffs[3].Change)
+	assert.Equal(t, 1, diffs[3].Actual)
+
+	assert.Equal(t, "Profile", diffs[4].Path.String())
+	assert.Equal(t, Added, diffs[4].Change)
+}
+
+func TestFindDifferences_StructuralTypes_ShouldRecurseIntoCompatibleNestedTypes(t *testing.T) {
+	// Arrange
+	dto := personDTO{
+		Nome:    "Alice",
+		Idade:   30,
+		Profile: profileDTO{Bio: "dev", Address: models.Address{City: "Recife"}},
+	}
+	pessoa := models.Pessoa{Nome: "Alice", Idade: 31}
+	person := models.Person{Name: "Alice", Profile: models.Profile{Bio: "dev", Address: models.Address{City: "Natal"}}}
+
+	// Act
+	pessoaDiffs := FindDifferencesWithOptions(dto, pessoa, WithStructuralTypes())
+	personDiffs := FindDifferencesWithOptions(dto, person, WithStructuralTypes(),
+		WithFieldMapping(personDTO{}, models.Person{}, map[string]string{"Nome": "Name"}))
+
+	// Assert
+	assert.Len(t, pessoaDiffs, 3)
+	assert.Equal(t, "Idade", pessoaDiffs[0].Path.String())
+	assert.Equal(t, int32(30), pessoaDiffs[0].Expected)
+	assert.Equal(t, 31, pessoaDiffs[0].Actual)
+	assert.Equal(t, "Profile", pessoaDiffs[1].Path.String())
+	assert.Equal(t, Removed, pessoaDiffs[1].Change)
+	assert.Equal(t, "Ativo", pessoaDiffs[2].Path.String())
+	assert.Equal(t, Added, pessoaDiffs[2].Change)
+
+	var paths []string
+	for _, diff := range personDiffs {
+		paths = append(paths, diff.Path.String())
+	}
+	assert.Equal(t, []string{"Idade", "Profile.Address.City", "Profile.Tags", "ID"}, paths)
+}
+
+func TestFindDifferences_StructuralTypes_ShouldReportArraysOfDifferentLengths(t *testing.T) {
+	// Act
+	longer := FindDifferencesWithOptions([4]int{1, 2, 3, 4}, [3]int{1, 2, 3}, WithStructuralTypes())
+	shorter := FindDifferencesWithOptions([2]int{1, 2}, [3]int{1, 2, 3}, WithStructuralTypes())
+
+	// Assert
+	assert.Len(t, longer, 1)
+	assert.Equal(t, TypeChanged, longer[0].Change)
+	assert.Equal(t, "[4]int", longer[0].Expected)
+	assert.Equal(t, "[3]int", longer[0].Actual)
+
+	assert.Len(t, shorter, 1)
+	assert.Equal(t, TypeChanged, shorter[0].Change)
+	assert.Equal(t, "[2]int", shorter[0].Expected)
+	assert.Equal(t, "[3]int", shorter[0].Actual)
+}
+
+func TestFindDifferences_StructuralTypes_ShouldCompareArraysOfSameLength(t *testing.T) {
+	// Act
+	diffs := FindDifferencesWithOptions([3]int{1, 2, 3}, [3]int32{1, 2, 4}, WithStructuralTypes())
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "[2]", diffs[0].Path.String())
+}
@@ -0,0 +1,75 @@
+package diffanalyzer
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldTag is the parsed form of a `diff` struct tag. The first element
+// renames the field in paths and "-" skips it entirely; the following
+// elements declare how the field is compared:
+//
+//	Secret   string    `diff:"-"`
+//	Nome     string    `diff:"name"`
+//	Tags     []string  `diff:",unordered"`
+//	ID       int       `diff:",key"`
+//	Price    float64   `diff:"price,tolerance=0.01"`
+//
+// Comparison modifiers also apply to the elements of a tagged slice, array,
+// map or pointer, so `diff:",tolerance=0.01"` on a []float64 applies to each
+// element.
+type fieldTag struct {
+	skip         bool
+	name         string
+	key          bool
+	unordered    bool
+	tolerance    float64
+	hasTolerance bool
+}
+
+func parseFieldTag(field reflect.StructField) fieldTag {
+	tag, ok := field.Tag.Lookup("diff")
+	if !ok {
+		return fieldTag{}
+	}
+	if tag == "-" {
+		return fieldTag{skip: true}
+	}
+
+	parts := strings.Split(tag, ",")
+	parsed := fieldTag{name: strings.TrimSpace(parts[0])}
+	for _, part := range parts[1:] {
+		option, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch option {
+		case "key":
+			parsed.key = true
+		case "unordered":
+			parsed.unordered = true
+		case "tolerance":
+			if tolerance, err := strconv.ParseFloat(value, 64); err == nil {
+				parsed.tolerance = tolerance
+				parsed.hasTolerance = true
+			}
+		}
+	}
+	return parsed
+}
+
+// fieldName returns the name used for field in paths and formatted output.
+func fieldName(field reflect.StructField, tag fieldTag) string {
+	if tag.name != "" {
+		return tag.name
+	}
+	return field.Name
+}
+
+// apply overrides the settings of node declared by the tag.
+func (t fieldTag) apply(node *nodeOptions) {
+	if t.unordered {
+		node.unordered = true
+	}
+	if t.hasTolerance {
+		node.tolerance.Absolute = t.tolerance
+	}
+}
@@ -0,0 +1,92 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type taggedProduct struct {
+	SKU      string    `diff:"sku"`
+	Secret   string    `diff:"-"`
+	Price    float64   `diff:"price,tolerance=0.01"`
+	Readings []float64 `diff:",tolerance=0.5"`
+	Tags     []string  `diff:",unordered"`
+}
+
+func TestFindDifferences_SkipTag_ShouldIgnoreField(t *testing.T) {
+	// Arrange
+	product1 := taggedProduct{SKU: "A1", Secret: "old"}
+	product2 := taggedProduct{SKU: "A1", Secret: "new"}
+
+	// Act
+	diffs := FindDifferences(product1, product2)
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFindDifferences_NameTag_ShouldRenamePathSegment(t *testing.T) {
+	// Arrange
+	product1 := taggedProduct{SKU: "A1"}
+	product2 := taggedProduct{SKU: "B2"}
+
+	// Act
+	diffs := FindDifferences(product1, product2)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "sku", diffs[0].Path.String())
+}
+
+func TestFindDifferences_ToleranceTag_ShouldAcceptSmallFloatDifferences(t *testing.T) {
+	// Arrange
+	product1 := taggedProduct{Price: 10.00, Readings: []float64{1.0, 2.0}}
+	product2 := taggedProduct{Price: 10.005, Readings: []float64{1.4, 2.6}}
+
+	// Act
+	diffs := FindDifferences(product1, product2)
+
+	// Assert
+	assert.Len(t, diffs, 1, "Only the reading outside its tolerance should differ")
+	assert.Equal(t, "Readings.[1]", diffs[0].Path.String())
+}
+
+func TestFindDifferences_ToleranceTag_ShouldReportLargeFloatDifferences(t *testing.T) {
+	// Arrange
+	product1 := taggedProduct{Price: 10.00}
+	product2 := taggedProduct{Price: 10.02}
+
+	// Act
+	diffs := FindDifferences(product1, product2)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "price", diffs[0].Path.String())
+}
+
+func TestFindDifferences_UnorderedTag_ShouldIgnoreOrder(t *testing.T) {
+	// Arrange
+	product1 := taggedProduct{Tags: []string{"sale", "new"}}
+	product2 := taggedProduct{Tags: []string{"new", "sale"}}
+
+	// Act
+	diffs := FindDifferences(product1, product2)
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFormatComparisonValue_TaggedStruct_ShouldHonorDiffTags(t *testing.T) {
+	// Arrange
+	product := taggedProduct{SKU: "A1", Secret: "hidden", Price: 9.5}
+
+	// Act
+	result := FormatComparisonValue(product)
+	testOutput := FormatTestOutput(product)
+
+	// Assert
+	assert.Equal(t, `{sku: "A1", price: 9.5, Readings: nil, Tags: nil}`, result)
+	assert.NotContains(t, testOutput, "hidden")
+	assert.Contains(t, testOutput, "sku:")
+}
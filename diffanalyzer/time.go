@@ -0,0 +1,122 @@
+package diffanalyzer
+
+import (
+	"reflect"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// WithTimeTruncation truncates times to a multiple of d, e.g. time.Second,
+// before comparing them, so that precision lost in storage is not reported.
+func WithTimeTruncation(d time.Duration) Option {
+	return func(o *options) {
+		o.node.timeTruncation = d
+	}
+}
+
+// WithTimeTolerance treats times and durations that are no more than d apart
+// as equal.
+func WithTimeTolerance(d time.Duration) Option {
+	return func(o *options) {
+		o.node.timeTolerance = d
+	}
+}
+
+// WithWallClockTime compares times by the date and clock they read in their
+// own time zone, ignoring the zone itself: 10:00 UTC equals 10:00 -03:00.
+// By default times are compared as instants, so the same instant in two
+// zones is equal and 10:00 UTC differs from 10:00 -03:00.
+func WithWallClockTime() Option {
+	return func(o *options) {
+		o.node.wallClock = true
+	}
+}
+
+// compareTime compares two time.Time values as instants with Equal, or two
+// time.Duration values, under the time options of their node. It reports
+// whether the values were of either type.
+func (c *comparer) compareTime(expected, actual reflect.Value, path Path, tag fieldTag) bool {
+	t := expected.Type()
+	if t != actual.Type() || (t != timeType && t != durationType) || !expected.CanInterface() || !actual.CanInterface() {
+		return false
+	}
+
+	node := c.nodeOptions(path, t, tag)
+	var delta time.Duration
+	if t == timeType {
+		e, a := expected.Interface().(time.Time), actual.Interface().(time.Time)
+		if node.wallClock {
+			e, a = wallClock(e), wallClock(a)
+		}
+		if node.timeTruncation > 0 {
+			e, a = e.Truncate(node.timeTruncation), a.Truncate(node.timeTruncation)
+		}
+		if e.Equal(a) {
+			return true
+		}
+		delta = a.Sub(e)
+	} else {
+		delta = actual.Interface().(time.Duration) - expected.Interface().(time.Duration)
+	}
+
+	if delta.Abs() > node.timeTolerance {
+		c.report(FieldDiff{
+			Path:     path,
+			Expected: expected.Interface(),
+			Actual:   actual.Interface(),
+			Change:   Modified,
+		})
+	}
+	return true
+}
+
+// wallClock returns the time reading the same date and clock as t in UTC.
+func wallClock(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+	return time.Date(year, month, day, hour, minute, second, t.Nanosecond(), time.UTC)
+}
+
+// FormatDelta renders how far actual is from expected when both are
+// time.Time or both time.Duration values, e.g. "+3m12s" or "-1h0m0s", and
+// returns "" otherwise.
+func FormatDelta(expected, actual interface{}) string {
+	var delta time.Duration
+	switch e := expected.(type) {
+	case time.Time:
+		a, ok := actual.(time.Time)
+		if !ok {
+			return ""
+		}
+		delta = a.Sub(e)
+	case time.Duration:
+		a, ok := actual.(time.Duration)
+		if !ok {
+			return ""
+		}
+		delta = a - e
+	default:
+		return ""
+	}
+
+	if delta < 0 {
+		return delta.String()
+	}
+	return "+" + delta.String()
+}
+
+// formatTime renders v in RFC 3339 when it is a time.Time, and as e.g.
+// "1h30m0s" when it is a time.Duration.
+func formatTime(v reflect.Value) (string, bool) {
+	switch {
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).String(), true
+	case v.Type() == timeType && v.CanInterface():
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), true
+	}
+	return "", false
+}
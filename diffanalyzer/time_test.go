@@ -0,0 +1,110 @@
+package diffanalyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type booking struct {
+	Guest    string
+	CheckIn  time.Time
+	Duration time.Duration
+}
+
+var checkIn = time.Date(2024, time.March, 10, 14, 0, 0, 0, time.UTC)
+
+func TestFindDifferences_SameInstantInDifferentZones_ShouldBeEqual(t *testing.T) {
+	// Arrange
+	recife := time.FixedZone("BRT", -3*60*60)
+	expected := booking{CheckIn: checkIn}
+	actual := booking{CheckIn: checkIn.In(recife)}
+
+	// Act
+	diffs := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFindDifferences_MonotonicReading_ShouldBeIgnored(t *testing.T) {
+	// Arrange
+	now := time.Now()
+
+	// Act
+	diffs := FindDifferences(booking{CheckIn: now}, booking{CheckIn: now.Round(0)})
+
+	// Assert
+	assert.Empty(t, diffs)
+}
+
+func TestFindDifferences_DifferentInstants_ShouldReportTimes(t *testing.T) {
+	// Arrange
+	later := checkIn.Add(3*time.Minute + 12*time.Second)
+
+	// Act
+	diffs := FindDifferences(booking{CheckIn: checkIn}, booking{CheckIn: later})
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "CheckIn", diffs[0].Path.String())
+	assert.Equal(t, Modified, diffs[0].Change)
+	assert.Equal(t, checkIn, diffs[0].Expected)
+	assert.Equal(t, later, diffs[0].Actual)
+	assert.Equal(t, "+3m12s", FormatDelta(diffs[0].Expected, diffs[0].Actual))
+}
+
+func TestFindDifferences_TimeTruncation_ShouldIgnoreSubUnitDifferences(t *testing.T) {
+	// Arrange
+	expected := booking{CheckIn: checkIn.Add(100 * time.Millisecond)}
+	actual := booking{CheckIn: checkIn.Add(900 * time.Millisecond)}
+
+	// Act
+	truncated := FindDifferencesWithOptions(expected, actual, WithTimeTruncation(time.Second))
+	exact := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Empty(t, truncated)
+	assert.Len(t, exact, 1)
+}
+
+func TestFindDifferences_TimeTolerance_ShouldApplyToTimesAndDurations(t *testing.T) {
+	// Arrange
+	expected := booking{CheckIn: checkIn, Duration: 2 * time.Hour}
+	actual := booking{CheckIn: checkIn.Add(-30 * time.Second), Duration: 2*time.Hour + 2*time.Minute}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithTimeTolerance(time.Minute))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Duration", diffs[0].Path.String())
+	assert.Equal(t, "+2m0s", FormatDelta(diffs[0].Expected, diffs[0].Actual))
+}
+
+func TestFindDifferences_WallClockTime_ShouldIgnoreTimeZone(t *testing.T) {
+	// Arrange
+	recife := time.FixedZone("BRT", -3*60*60)
+	expected := booking{CheckIn: checkIn}
+	actual := booking{CheckIn: time.Date(2024, time.March, 10, 14, 0, 0, 0, recife)}
+
+	// Act
+	wallClock := FindDifferencesWithOptions(expected, actual, ForPath("CheckIn", WithWallClockTime()))
+	instants := FindDifferences(expected, actual)
+
+	// Assert
+	assert.Empty(t, wallClock)
+	assert.Len(t, instants, 1)
+	assert.Equal(t, "+3h0m0s", FormatDelta(instants[0].Expected, instants[0].Actual))
+}
+
+func TestFormatDiffValue_Times_ShouldRenderRFC3339(t *testing.T) {
+	// Act & Assert
+	assert.Equal(t, "2024-03-10T14:00:00Z", FormatDiffValue(checkIn))
+	assert.Equal(t, "1h30m0s", FormatDiffValue(90*time.Minute))
+	assert.Equal(t, `{Guest: "Ana", CheckIn: 2024-03-10T14:00:00Z, Duration: 2h0m0s}`,
+		FormatComparisonValue(booking{Guest: "Ana", CheckIn: checkIn, Duration: 2 * time.Hour}))
+	assert.Equal(t, "-5s", FormatDelta(5*time.Second, time.Duration(0)))
+	assert.Equal(t, "", FormatDelta(checkIn, "later"))
+}
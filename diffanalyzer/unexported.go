@@ -0,0 +1,162 @@
+package diffanalyzer
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// UnexportedPolicy selects how unexported struct fields are compared.
+type UnexportedPolicy int
+
+const (
+	// SkipUnexported ignores unexported fields. Embedded unexported structs
+	// are still traversed for their exported fields, and structs without any
+	// exported field (time.Time, sync.Mutex, ...) are compared as a whole
+	// with reflect.DeepEqual, so that their differences are not lost.
+	SkipUnexported UnexportedPolicy = iota
+	// CompareUnexported compares unexported fields like exported ones,
+	// reading them through the reflect accessors only. Differences found in
+	// unexported fields report their values as formatted strings, so private
+	// values never leave the package.
+	CompareUnexported
+)
+
+// WithUnexportedFields sets the policy for unexported struct fields. The
+// default is SkipUnexported.
+func WithUnexportedFields(policy UnexportedPolicy) Option {
+	return func(o *options) {
+		o.unexported = policy
+	}
+}
+
+// comparesField reports whether field takes part in the comparison under
+// policy.
+func (policy UnexportedPolicy) comparesField(field reflect.StructField) bool {
+	if field.IsExported() || policy == CompareUnexported {
+		return true
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return field.Anonymous && t.Kind() == reflect.Struct
+}
+
+// isOpaque reports whether t is a struct that has fields but none of them
+// exported, so that skipping unexported fields would hide every difference.
+func (policy UnexportedPolicy) isOpaque(t reflect.Type) bool {
+	if policy == CompareUnexported || t.NumField() == 0 {
+		return false
+	}
+	for i := range t.NumField() {
+		if policy.comparesField(t.Field(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// reportedValue returns the value to report for v in a FieldDiff. Values
+// read from unexported fields are never handed out as they are: they are
+// reported as their formatted string instead.
+func reportedValue(v reflect.Value) interface{} {
+	switch {
+	case !v.IsValid():
+		return nil
+	case v.CanInterface():
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Array, reflect.Map:
+		return formatValue(v, make(map[visit]bool))
+	}
+	return fmt.Sprint(v)
+}
+
+// deepEqual reports whether expected and actual are deeply equal in the
+// sense of reflect.DeepEqual. Values read from unexported fields cannot be
+// passed to reflect.DeepEqual, so they are walked with the kind accessors.
+func deepEqual(expected, actual reflect.Value) bool {
+	if !expected.IsValid() || !actual.IsValid() {
+		return expected.IsValid() == actual.IsValid()
+	}
+	if expected.CanInterface() && actual.CanInterface() {
+		return reflect.DeepEqual(expected.Interface(), actual.Interface())
+	}
+	return readOnlyEqual(expected, actual, make(map[[2]visit]bool))
+}
+
+func readOnlyEqual(expected, actual reflect.Value, visited map[[2]visit]bool) bool {
+	if !expected.IsValid() || !actual.IsValid() {
+		return expected.IsValid() == actual.IsValid()
+	}
+	if expected.Type() != actual.Type() {
+		return false
+	}
+	switch expected.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if expected.IsNil() != actual.IsNil() {
+			return false
+		}
+		pair := [2]visit{newVisit(expected), newVisit(actual)}
+		if pair[0] == pair[1] || visited[pair] {
+			return true
+		}
+		visited[pair] = true
+	}
+
+	switch expected.Kind() {
+	case reflect.Bool:
+		return expected.Bool() == actual.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return expected.Int() == actual.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return expected.Uint() == actual.Uint()
+	case reflect.Float32, reflect.Float64:
+		return expected.Float() == actual.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return expected.Complex() == actual.Complex()
+	case reflect.String:
+		return expected.String() == actual.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return expected.Pointer() == actual.Pointer()
+	case reflect.Func:
+		return expected.IsNil() && actual.IsNil()
+	case reflect.Ptr:
+		return readOnlyEqual(expected.Elem(), actual.Elem(), visited)
+	case reflect.Interface:
+		if expected.IsNil() || actual.IsNil() {
+			return expected.IsNil() && actual.IsNil()
+		}
+		return readOnlyEqual(expected.Elem(), actual.Elem(), visited)
+	case reflect.Array, reflect.Slice:
+		if expected.Len() != actual.Len() {
+			return false
+		}
+		for i := range expected.Len() {
+			if !readOnlyEqual(expected.Index(i), actual.Index(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if expected.Len() != actual.Len() {
+			return false
+		}
+		for iter := expected.MapRange(); iter.Next(); {
+			value := actual.MapIndex(iter.Key())
+			if !value.IsValid() || !readOnlyEqual(iter.Value(), value, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := range expected.NumField() {
+			if !readOnlyEqual(expected.Field(i), actual.Field(i), visited) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
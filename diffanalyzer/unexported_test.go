@@ -0,0 +1,140 @@
+package diffanalyzer
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type account struct {
+	Owner   string
+	balance int
+	mu      sync.Mutex
+	history []string
+}
+
+type vault struct {
+	secrets map[string]auditInfo
+}
+
+type auditInfo struct {
+	CreatedBy string
+	revision  int
+}
+
+type document struct {
+	auditInfo
+	Title   string
+	Created time.Time
+}
+
+func TestFindDifferences_UnexportedFields_ShouldNotPanic(t *testing.T) {
+	// Arrange
+	account1 := &account{Owner: "Alice", balance: 10, history: []string{"open"}}
+	account2 := &account{Owner: "Alice", balance: 20, history: []string{"open", "deposit"}}
+
+	// Act & Assert
+	assert.NotPanics(t, func() {
+		diffs := FindDifferences(account1, account2)
+		assert.Empty(t, diffs, "Unexported fields are skipped by default")
+	})
+}
+
+func TestFindDifferencesWithOptions_CompareUnexported_ShouldReportPrivateFields(t *testing.T) {
+	// Arrange
+	account1 := &account{Owner: "Alice", balance: 10, history: []string{"open"}}
+	account2 := &account{Owner: "Alice", balance: 20, history: []string{"open", "deposit"}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(account1, account2, WithUnexportedFields(CompareUnexported))
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "balance", diffs[0].Path.String())
+	assert.Equal(t, "10", diffs[0].Expected, "Private values are reported formatted")
+	assert.Equal(t, "20", diffs[0].Actual)
+	assert.Equal(t, "history.[1]", diffs[1].Path.String())
+	assert.Equal(t, Added, diffs[1].Change)
+}
+
+func TestFindDifferencesWithOptions_CompareUnexportedComposites_ShouldReportFormattedValues(t *testing.T) {
+	// Arrange
+	vault1 := &vault{secrets: map[string]auditInfo{"alice": {CreatedBy: "alice", revision: 1}}}
+	vault2 := &vault{secrets: map[string]auditInfo{"alice": {CreatedBy: "alice", revision: 1}, "bob": {CreatedBy: "bob", revision: 3}}}
+
+	// Act
+	diffs := FindDifferencesWithOptions(vault1, vault2, WithUnexportedFields(CompareUnexported))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "secrets.[bob]", diffs[0].Path.String())
+	assert.Equal(t, Added, diffs[0].Change)
+	assert.Equal(t, `{CreatedBy: "bob", revision: 3}`, diffs[0].Actual)
+}
+
+func TestFindDifferencesWithOptions_CompareUnexportedLockedMutex_ShouldLeaveTheLockUntouched(t *testing.T) {
+	// Arrange
+	account1 := &account{Owner: "Alice", balance: 10}
+	account2 := &account{Owner: "Alice", balance: 10}
+	account1.mu.Lock()
+
+	// Act
+	diffs := FindDifferencesWithOptions(account1, account2, WithUnexportedFields(CompareUnexported))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.True(t, strings.HasPrefix(diffs[0].Path.String(), "mu."))
+	assert.False(t, account1.mu.TryLock(), "The compared mutex is still held")
+	account1.mu.Unlock()
+}
+
+func TestFindDifferencesWithOptions_CompareUnexportedByValue_ShouldNotPanic(t *testing.T) {
+	// Arrange
+	doc1 := document{auditInfo: auditInfo{CreatedBy: "alice", revision: 1}, Title: "Draft"}
+	doc2 := document{auditInfo: auditInfo{CreatedBy: "alice", revision: 2}, Title: "Draft"}
+
+	// Act
+	diffs := FindDifferencesWithOptions(doc1, doc2, WithUnexportedFields(CompareUnexported))
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "auditInfo.revision", diffs[0].Path.String())
+}
+
+func TestFindDifferences_EmbeddedUnexportedStruct_ShouldCompareExportedFields(t *testing.T) {
+	// Arrange
+	doc1 := document{auditInfo: auditInfo{CreatedBy: "alice", revision: 1}}
+	doc2 := document{auditInfo: auditInfo{CreatedBy: "bob", revision: 2}}
+
+	// Act
+	diffs := FindDifferences(doc1, doc2)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "auditInfo.CreatedBy", diffs[0].Path.String())
+}
+
+func TestFindDifferences_StructWithoutExportedFields_ShouldBeComparedAsAWhole(t *testing.T) {
+	// Arrange
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	doc1 := document{Title: "Draft", Created: created}
+	doc2 := document{Title: "Draft", Created: created.Add(time.Hour)}
+
+	// Act
+	diffs := FindDifferences(doc1, doc2)
+
+	// Assert
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "Created", diffs[0].Path.String())
+	assert.Equal(t, created, diffs[0].Expected)
+}
+
+func TestFormatComparisonValue_UnexportedFields_ShouldNotPanic(t *testing.T) {
+	assert.NotPanics(t, func() {
+		FormatComparisonValue(document{Title: "Draft"})
+		FormatTestOutput(&account{Owner: "Alice", balance: 10})
+	})
+}
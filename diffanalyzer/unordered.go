@@ -0,0 +1,83 @@
+package diffanalyzer
+
+import "reflect"
+
+// WithUnorderedSlices compares slices as multisets: the order of the elements
+// is ignored and only elements missing from either side are reported, each
+// duplicate counting on its own. Combine it with ForPath or ForType to treat
+// only some slices as unordered.
+func WithUnorderedSlices() Option {
+	return func(o *options) {
+		o.node.unordered = true
+	}
+}
+
+// maxPairwiseComparisons bounds the number of full comparisons
+// compareUnordered makes to pair elements that are not deeply equal.
+const maxPairwiseComparisons = 1 << 16
+
+// compareUnordered pairs every expected element with a deeply equal, not yet
+// matched, actual element. The elements left are paired by full comparisons,
+// so that elements equal only under the options (a tolerance, an ignored
+// field) still match, as long as there are few enough of them. Expected
+// elements left without a pair are reported as removed and actual elements
+// left without a pair as added.
+func (c *comparer) compareUnordered(expected, actual reflect.Value, path Path, tag fieldTag) {
+	expectedClasses, actualClasses := elementClasses(expected, actual)
+	available := make(map[int][]int)
+	for j, class := range actualClasses {
+		available[class] = append(available[class], j)
+	}
+
+	matched := make([]bool, actual.Len())
+	var missing []int
+	for i, class := range expectedClasses {
+		if js := available[class]; len(js) > 0 {
+			matched[js[0]] = true
+			available[class] = js[1:]
+			continue
+		}
+		missing = append(missing, i)
+	}
+
+	var extra []int
+	for j := range actual.Len() {
+		if !matched[j] {
+			extra = append(extra, j)
+		}
+	}
+
+	pairwise := len(missing)*len(extra) <= maxPairwiseComparisons
+	for _, i := range missing {
+		found := false
+		for _, j := range extra {
+			if !pairwise || found {
+				break
+			}
+			if !matched[j] && c.equal(expected.Index(i), actual.Index(j), path.Index(i), tag) {
+				matched[j] = true
+				found = true
+			}
+		}
+		if !found {
+			c.report(FieldDiff{
+				Path:     path.Index(i),
+				Expected: reportedValue(expected.Index(i)),
+				Actual:   nil,
+				Change:   Removed,
+			})
+		}
+	}
+
+	for j := range actual.Len() {
+		if matched[j] {
+			continue
+		}
+		c.report(FieldDiff{
+			Path:     path.Index(j),
+			Expected: nil,
+			Actual:   reportedValue(actual.Index(j)),
+			Change:   Added,
+		})
+	}
+}
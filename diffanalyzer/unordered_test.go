@@ -0,0 +1,129 @@
+package diffanalyzer
+
+import (
+	"testing"
+
+	"github.com/seu-usuario/meu-projeto/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDifferencesWithOptions_UnorderedSlices_ShouldIgnoreOrder(t *testing.T) {
+	// Arrange
+	person1 := models.Person{
+		Emails:  []string{"a@x.com", "b@x.com"},
+		Profile: models.Profile{Tags: []string{"go", "api"}},
+	}
+	person2 := models.Person{
+		Emails:  []string{"b@x.com", "a@x.com"},
+		Profile: models.Profile{Tags: []string{"api", "go"}},
+	}
+
+	// Act
+	diffs := FindDifferencesWithOptions(person1, person2, WithUnorderedSlices())
+
+	// Assert
+	assert.Empty(t, diffs, "Reordered slices should be equal in unordered mode")
+}
+
+func TestFindDifferencesWithOptions_UnorderedSlices_ShouldReportMissingElements(t *testing.T) {
+	// Arrange
+	expected := []string{"go", "api", "backend"}
+	actual := []string{"frontend", "go", "api"}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithUnorderedSlices())
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "[2]", diffs[0].Path.String())
+	assert.Equal(t, Removed, diffs[0].Change)
+	assert.Equal(t, "backend", diffs[0].Expected)
+	assert.Equal(t, "[0]", diffs[1].Path.String())
+	assert.Equal(t, Added, diffs[1].Change)
+	assert.Equal(t, "frontend", diffs[1].Actual)
+}
+
+func TestFindDifferencesWithOptions_UnorderedSlicesWithDuplicates_ShouldCountEachOccurrence(t *testing.T) {
+	// Arrange
+	expected := []string{"go", "go", "api"}
+	actual := []string{"api", "go", "api"}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithUnorderedSlices())
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "[1]", diffs[0].Path.String())
+	assert.Equal(t, Removed, diffs[0].Change)
+	assert.Equal(t, "go", diffs[0].Expected)
+	assert.Equal(t, "[2]", diffs[1].Path.String())
+	assert.Equal(t, Added, diffs[1].Change)
+	assert.Equal(t, "api", diffs[1].Actual)
+}
+
+func TestFindDifferencesWithOptions_UnorderedForPath_ShouldOnlyAffectThatPath(t *testing.T) {
+	// Arrange
+	person1 := models.Person{
+		Emails:  []string{"a@x.com", "b@x.com"},
+		Profile: models.Profile{Tags: []string{"go", "api"}},
+	}
+	person2 := models.Person{
+		Emails:  []string{"b@x.com", "a@x.com"},
+		Profile: models.Profile{Tags: []string{"api", "go"}},
+	}
+
+	// Act
+	diffs := FindDifferencesWithOptions(person1, person2, ForPath("Profile.Tags", WithUnorderedSlices()))
+
+	// Assert
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.Contains(t, diff.Path.String(), "Emails", "Only the ordered Emails slice should differ")
+	}
+}
+
+func TestFindDifferencesWithOptions_UnorderedForType_ShouldOnlyAffectThatType(t *testing.T) {
+	// Arrange
+	expected := models.ItemCollection{Items: []models.Item{{ID: 1}, {ID: 2}}}
+	actual := models.ItemCollection{Items: []models.Item{{ID: 2}, {ID: 1}}}
+
+	// Act
+	withType := FindDifferencesWithOptions(expected, actual, ForType([]models.Item{}, WithUnorderedSlices()))
+	withOtherType := FindDifferencesWithOptions(expected, actual, ForType([]string{}, WithUnorderedSlices()))
+
+	// Assert
+	assert.Empty(t, withType)
+	assert.NotEmpty(t, withOtherType)
+}
+
+func TestFindDifferencesWithOptions_LargeUnorderedSlices_ShouldMatchEqualElementsCheaply(t *testing.T) {
+	// Arrange
+	var expected, actual []models.Item
+	for i := range 4000 {
+		expected = append(expected, models.Item{ID: i, Status: "active"})
+		actual = append(actual, models.Item{ID: 3999 - i, Status: "active"})
+	}
+	actual[0].Status = "inactive"
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithUnorderedSlices())
+
+	// Assert
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "[3999]", diffs[0].Path.String())
+	assert.Equal(t, Removed, diffs[0].Change)
+	assert.Equal(t, "[0]", diffs[1].Path.String())
+	assert.Equal(t, Added, diffs[1].Change)
+}
+
+func TestFindDifferencesWithOptions_UnorderedSlicesWithTolerance_ShouldPairEqualUnderOptions(t *testing.T) {
+	// Arrange
+	expected := []float64{1.0, 2.0, 3.0}
+	actual := []float64{3.001, 1.0, 2.0}
+
+	// Act
+	diffs := FindDifferencesWithOptions(expected, actual, WithUnorderedSlices(), WithAbsoluteTolerance(0.01))
+
+	// Assert
+	assert.Empty(t, diffs)
+}
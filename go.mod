@@ -2,9 +2,13 @@ module github.com/seu-usuario/meu-projeto
 
 go 1.24.3
 
+require (
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/text v0.34.0
+)
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.10.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
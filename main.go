@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/seu-usuario/meu-projeto/diffanalyzer"
 	"github.com/seu-usuario/meu-projeto/models"
 )
 
@@ -38,7 +39,7 @@ func main() {
 		},
 	}
 
-	diffs := FindDifferences(expected, actual)
+	diffs := diffanalyzer.FindDifferences(expected, actual)
 	printDifferences("Person Comparison", diffs)
 
 	fmt.Println("\n=== EXAMPLE 2: Data Types Comparison ===")
@@ -59,7 +60,7 @@ func main() {
 		StringValue:  "world",
 	}
 
-	diffs2 := FindDifferences(data1, data2)
+	diffs2 := diffanalyzer.FindDifferences(data1, data2)
 	printDifferences("Data Types Comparison", diffs2)
 
 	fmt.Println("\n=== EXAMPLE 3: Map Comparison ===")
@@ -88,7 +89,7 @@ func main() {
 		},
 	}
 
-	diffs3 := FindDifferences(container1, container2)
+	diffs3 := diffanalyzer.FindDifferences(container1, container2)
 	printDifferences("Map Comparison", diffs3)
 
 	fmt.Println("\n=== EXAMPLE 4: Nested Map Comparison ===")
@@ -118,7 +119,7 @@ func main() {
 		},
 	}
 
-	diffs4 := FindDifferences(nested1, nested2)
+	diffs4 := diffanalyzer.FindDifferences(nested1, nested2)
 	printDifferences("Nested Map Comparison", diffs4)
 
 	fmt.Println("\n=== EXAMPLE 5: Slice Length Differences ===")
@@ -135,7 +136,7 @@ func main() {
 		Emails: []string{"bob@company.com"}, // slice smaller
 	}
 
-	diffs5 := FindDifferences(person1, person2)
+	diffs5 := diffanalyzer.FindDifferences(person1, person2)
 	printDifferences("Slice Length Comparison", diffs5)
 
 	fmt.Println("\n=== EXAMPLE 6: Nil vs Empty Comparison ===")
@@ -152,7 +153,7 @@ func main() {
 		Emails: []string{}, // empty slice
 	}
 
-	diffs6 := FindDifferences(nilPerson, emptyPerson)
+	diffs6 := diffanalyzer.FindDifferences(nilPerson, emptyPerson)
 	printDifferences("Nil vs Empty Comparison", diffs6)
 
 	fmt.Println("\n=== EXAMPLE 7: Complex Person with Maps ===")
@@ -189,7 +190,7 @@ func main() {
 		},
 	}
 
-	diffs7 := FindDifferences(complexContainer1, complexContainer2)
+	diffs7 := diffanalyzer.FindDifferences(complexContainer1, complexContainer2)
 	printDifferences("Complex Person Map Comparison", diffs7)
 
 	fmt.Println("\n=== EXAMPLE 8: Pessoa (Portuguese) ===")
@@ -210,7 +211,7 @@ func main() {
 	}
 
 	for i, pessoa := range pessoas {
-		fmt.Printf("Pessoa %d: %s\n", i+1, formatTestOutput(pessoa))
+		fmt.Printf("Pessoa %d: %s\n", i+1, diffanalyzer.FormatTestOutput(pessoa))
 	}
 
 	pessoaModificada := models.Pessoa{
@@ -220,7 +221,7 @@ func main() {
 		Emails: []string{"joao@newcompany.com"}, // email modified
 	}
 
-	diffs8 := FindDifferences(pessoas[0], pessoaModificada)
+	diffs8 := diffanalyzer.FindDifferences(pessoas[0], pessoaModificada)
 	printDifferences("Pessoa Comparison", diffs8)
 
 	fmt.Println("\n=== EXAMPLE 9: Slice of Structs Comparison ===")
@@ -241,7 +242,7 @@ func main() {
 		},
 	}
 
-	diffs9 := FindDifferences(expectedCollection, actualCollection)
+	diffs9 := diffanalyzer.FindDifferences(expectedCollection, actualCollection)
 	printDifferences("Slice of Structs Comparison", diffs9)
 
 	fmt.Println("\n=== EXAMPLE 10: Direct Slice Comparison ===")
@@ -258,7 +259,7 @@ func main() {
 		{ID: 5, Status: "active", Value: 250}, // Value changed
 	}
 
-	diffs10 := FindDifferences(expectedItems, actualItems)
+	diffs10 := diffanalyzer.FindDifferences(expectedItems, actualItems)
 	printDifferences("Direct Slice Comparison", diffs10)
 
 	fmt.Println("\n=== EXAMPLE 11: Formatter Comparison ===")
@@ -278,11 +279,11 @@ func main() {
 	}
 
 	fmt.Println("\n🚀 Exemplo como solicitado:")
-	fmt.Printf("expected: %s\n", formatComparisonValue(expectedItems))
-	fmt.Printf("actual  : %s\n", formatComparisonValue(actualItems))
+	fmt.Printf("expected: %s\n", diffanalyzer.FormatComparisonValue(expectedItems))
+	fmt.Printf("actual  : %s\n", diffanalyzer.FormatComparisonValue(actualItems))
 
 	// Encontra as diferenças
-	diffsItems := FindDifferences(expectedItems, actualItems)
+	diffsItems := diffanalyzer.FindDifferences(expectedItems, actualItems)
 
 	if len(diffsItems) > 0 {
 		fmt.Println("Field differences:")
@@ -297,8 +298,8 @@ func main() {
 
 	// Comparação usando formatTestOutput
 	fmt.Println("\n=== Comparação usando formatTestOutput ===")
-	fmt.Printf("expected: %s\n", formatTestOutput(expectedItems))
-	fmt.Printf("actual  : %s\n", formatTestOutput(actualItems))
+	fmt.Printf("expected: %s\n", diffanalyzer.FormatTestOutput(expectedItems))
+	fmt.Printf("actual  : %s\n", diffanalyzer.FormatTestOutput(actualItems))
 
 	fmt.Println("\n" + strings.Repeat("=", 60))
 
@@ -315,21 +316,21 @@ func main() {
 		"item2": {ID: 2, Status: "pending", Value: 250},  // Value diferente
 	}
 
-	fmt.Printf("expected: %s\n", formatComparisonValue(expectedMap))
-	fmt.Printf("actual  : %s\n", formatComparisonValue(actualMap))
+	fmt.Printf("expected: %s\n", diffanalyzer.FormatComparisonValue(expectedMap))
+	fmt.Printf("actual  : %s\n", diffanalyzer.FormatComparisonValue(actualMap))
 
-	diffsMapItems := FindDifferences(expectedMap, actualMap)
+	diffsMapItems := diffanalyzer.FindDifferences(expectedMap, actualMap)
 	if len(diffsMapItems) > 0 {
 		fmt.Println("Field differences:")
 		for _, diff := range diffsMapItems {
-			expectedStr := formatDiffValue(diff.Expected)
-			actualStr := formatDiffValue(diff.Actual)
+			expectedStr := diffanalyzer.FormatDiffValue(diff.Expected)
+			actualStr := diffanalyzer.FormatDiffValue(diff.Actual)
 			fmt.Printf("  └─ %s: %s ≠ %s\n", diff.Path, expectedStr, actualStr)
 		}
 	}
 }
 
-func printDifferences(title string, diffs []models.FieldDiff) {
+func printDifferences(title string, diffs []diffanalyzer.FieldDiff) {
 	fmt.Printf("\n%s:\n", title)
 	if len(diffs) == 0 {
 		fmt.Println("  No differences found!")
@@ -338,30 +339,11 @@ func printDifferences(title string, diffs []models.FieldDiff) {
 
 	fmt.Printf("  Found %d difference(s):\n", len(diffs))
 	for _, diff := range diffs {
-		expectedStr := formatDiffValue(diff.Expected)
-		actualStr := formatDiffValue(diff.Actual)
-		fmt.Printf("  └─ %s: %s ≠ %s\n", diff.Path, expectedStr, actualStr)
-	}
-}
-
-func formatDiffValue(value interface{}) string {
-	if value == nil {
-		return "<nil>"
-	}
-
-	switch v := value.(type) {
-	case string:
-		return fmt.Sprintf("%q", v)
-	case bool:
-		return fmt.Sprintf("%t", v)
-	case int, int8, int16, int32, int64:
-		return fmt.Sprintf("%v", v)
-	case uint, uint8, uint16, uint32, uint64:
-		return fmt.Sprintf("%v", v)
-	case float32, float64:
-		return fmt.Sprintf("%v", v)
-	default:
-		// Para tipos complexos, usa nosso formatter de comparação
-		return formatComparisonValue(v)
+		expectedStr := diffanalyzer.FormatDiffValue(diff.Expected)
+		actualStr := diffanalyzer.FormatDiffValue(diff.Actual)
+		if delta := diffanalyzer.FormatDelta(diff.Expected, diff.Actual); delta != "" {
+			actualStr += " (" + delta + ")"
+		}
+		fmt.Printf("  └─ %s [%s]: %s ≠ %s\n", diff.Path, diff.Change, expectedStr, actualStr)
 	}
 }
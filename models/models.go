@@ -25,12 +25,6 @@ type Person struct {
 	Profile Profile
 }
 
-type FieldDiff struct {
-	Path     string
-	Expected interface{}
-	Actual   interface{}
-}
-
 type DataTypes struct {
 	IntValue     int
 	Int8Value    int8